@@ -0,0 +1,121 @@
+package baconv
+
+// invalidDigit marks bytes that are not digits of an Alphabet.
+const invalidDigit = 0xFF
+
+// An Alphabet is a set of digits for integer conversion in base
+// len(digits), together with a precomputed table from byte to digit
+// value. Alphabets are immutable and safe for concurrent use.
+type Alphabet struct {
+	digits string
+	values [256]byte // digit value of each byte, or invalidDigit
+}
+
+var (
+	// base36 is the alphabet used by ParseUint and FormatUint.
+	base36 = NewAlphabet(digits, true, "")
+
+	// Base62 is the alphabet of digits, upper-case and lower-case
+	// letters, as used for short IDs.
+	Base62 = NewAlphabet("0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz", false, "")
+
+	// Crockford32 is Douglas Crockford's base 32 alphabet. It excludes
+	// I, L, O and U; parsing is case-insensitive and reads I and L
+	// as 1 and O as 0.
+	Crockford32 = NewAlphabet("0123456789ABCDEFGHJKMNPQRSTVWXYZ", true, "I1L1O0")
+)
+
+// NewAlphabet returns an Alphabet whose digits, in increasing value,
+// are the bytes of digits. If foldCase is set, ASCII letters are also
+// accepted in the other case when parsing. aliases lists pairs of
+// bytes: a byte that is accepted when parsing, followed by the digit
+// it stands for. Formatting always uses digits.
+//
+// NewAlphabet panics if digits has fewer than 2 or more than 255
+// bytes, or if a byte would stand for two different digit values.
+func NewAlphabet(digits string, foldCase bool, aliases string) *Alphabet {
+	if len(digits) < 2 || len(digits) > 255 {
+		panic("bconv: illegal Alphabet size " + Itoba(len(digits)))
+	}
+	if len(aliases)%2 != 0 {
+		panic("bconv: odd Alphabet aliases " + Quote(aliases))
+	}
+	a := &Alphabet{digits: digits}
+	for i := range a.values {
+		a.values[i] = invalidDigit
+	}
+	for i := 0; i < len(digits); i++ {
+		a.set(digits[i], byte(i), foldCase)
+	}
+	for i := 0; i < len(aliases); i += 2 {
+		v := a.values[aliases[i+1]]
+		if v == invalidDigit {
+			panic("bconv: Alphabet alias for unknown digit " + QuoteRune(rune(aliases[i+1])))
+		}
+		a.set(aliases[i], v, foldCase)
+	}
+	return a
+}
+
+func (a *Alphabet) set(c, v byte, foldCase bool) {
+	if old := a.values[c]; old != invalidDigit && old != v {
+		panic("bconv: ambiguous Alphabet digit " + QuoteRune(rune(c)))
+	}
+	a.values[c] = v
+	if foldCase && ('a' <= lower(c) && lower(c) <= 'z') {
+		a.set(c^('a'-'A'), v, false)
+	}
+}
+
+// Base returns the base of the alphabet, the number of its digits.
+func (a *Alphabet) Base() int {
+	return len(a.digits)
+}
+
+// String returns the digits of the alphabet.
+func (a *Alphabet) String() string {
+	return a.digits
+}
+
+// ParseUintAlphabet is like ParseUint but reads the digits of
+// alphabet a, in base a.Base(), and accepts no prefix.
+// The errors it returns have concrete type *NumError with
+// Func = "ParseUintAlphabet".
+func ParseUintAlphabet(ba []byte, a *Alphabet, bitSize int) (uint64, error) {
+	const fnParseUintAlphabet = "ParseUintAlphabet"
+
+	if len(ba) == 0 {
+		return 0, syntaxError(fnParseUintAlphabet, string(ba))
+	}
+	if bitSize == 0 {
+		bitSize = int(IntSize)
+	} else if bitSize < 0 || bitSize > 64 {
+		return 0, bitSizeError(fnParseUintAlphabet, string(ba), bitSize)
+	}
+	maxVal := uint64(1)<<uint(bitSize) - 1
+
+	// Same checks as in ParseUint.
+	base := len(a.digits)
+	cutoff := uintCutoff(base)
+	var n uint64
+	for _, c := range []byte(ba) {
+		d := a.values[c]
+		if d >= byte(base) {
+			return 0, syntaxError(fnParseUintAlphabet, string(ba))
+		}
+		var ok bool
+		if n, ok = addDigit(n, d, base, cutoff, maxVal); !ok {
+			return maxVal, rangeError(fnParseUintAlphabet, string(ba))
+		}
+	}
+
+	return n, nil
+}
+
+// AppendUintAlphabet appends the representation of the unsigned
+// integer i in the digits of alphabet a to dst and returns the
+// extended buffer.
+func AppendUintAlphabet(dst []byte, i uint64, a *Alphabet) []byte {
+	dst, _ = formatBitsIn(dst, i, a.digits, false, false, true)
+	return dst
+}
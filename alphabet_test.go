@@ -0,0 +1,141 @@
+package baconv
+
+import (
+	"reflect"
+	"testing"
+)
+
+type alphabetTest struct {
+	a   *Alphabet
+	in  uint64
+	out string
+}
+
+var alphabetTests = []alphabetTest{
+	{Base62, 0, "0"},
+	{Base62, 61, "z"},
+	{Base62, 62, "10"},
+	{Base62, 3843, "zz"},
+	{Base62, 1<<64 - 1, "LygHa16AHYF"},
+	{Crockford32, 0, "0"},
+	{Crockford32, 31, "Z"},
+	{Crockford32, 32, "10"},
+	{Crockford32, 1234, "16J"},
+	{Crockford32, 1<<64 - 1, "FZZZZZZZZZZZZ"},
+	{base36, 1<<64 - 1, "3w5e11264sgsf"},
+	{NewAlphabet("01", false, ""), 10, "1010"},
+	{NewAlphabet("ab", false, ""), 10, "baba"},
+	{NewAlphabet("0123456789", false, ""), 1234567890, "1234567890"},
+	{NewAlphabet("9876543210", false, ""), 1234567890, "8765432109"},
+}
+
+func TestAlphabet(t *testing.T) {
+	for _, test := range alphabetTests {
+		out := AppendUintAlphabet([]byte("x="), test.in, test.a)
+		if string(out) != "x="+test.out {
+			t.Errorf("AppendUintAlphabet(%v, %q) = %q, want %q", test.in, test.a, out, "x="+test.out)
+		}
+		n, err := ParseUintAlphabet([]byte(test.out), test.a, 64)
+		if err != nil || n != test.in {
+			t.Errorf("ParseUintAlphabet(%q, %q) = %v, %v, want %v", test.out, test.a, n, err, test.in)
+		}
+	}
+}
+
+type parseAlphabetTest struct {
+	a       *Alphabet
+	in      string
+	bitSize int
+	out     uint64
+	err     error
+}
+
+var parseAlphabetTests = []parseAlphabetTest{
+	{Base62, "", 64, 0, ErrSyntax},
+	{Base62, "Z", 64, 35, nil},
+	{Base62, "z", 64, 61, nil},
+	{Base62, "-1", 64, 0, ErrSyntax},
+	{Base62, "LygHa16AHYG", 64, 1<<64 - 1, ErrRange},
+	{Base62, "4gfFC3", 32, 1<<32 - 1, nil},
+	{Base62, "4gfFC4", 32, 1<<32 - 1, ErrRange},
+	{Crockford32, "16j", 64, 1234, nil},
+	{Crockford32, "1O", 64, 32, nil},
+	{Crockford32, "1o", 64, 32, nil},
+	{Crockford32, "IL", 64, 33, nil},
+	{Crockford32, "il", 64, 33, nil},
+	{Crockford32, "U", 64, 0, ErrSyntax},
+	{Crockford32, "1-2", 64, 0, ErrSyntax},
+	{Crockford32, "G000000000000", 64, 1<<64 - 1, ErrRange},
+}
+
+func TestParseUintAlphabet(t *testing.T) {
+	for _, test := range parseAlphabetTests {
+		out, err := ParseUintAlphabet([]byte(test.in), test.a, test.bitSize)
+		var wantErr error
+		if test.err != nil {
+			wantErr = &NumError{"ParseUintAlphabet", test.in, test.err}
+		}
+		if out != test.out || !reflect.DeepEqual(err, wantErr) {
+			t.Errorf("ParseUintAlphabet(%q, %q, %d) = %v, %v, want %v, %v",
+				test.in, test.a, test.bitSize, out, err, test.out, wantErr)
+		}
+	}
+}
+
+func TestParseUintAlphabetBitSize(t *testing.T) {
+	for i := range parseBitSizeTests {
+		test := &parseBitSizeTests[i]
+		testErr := test.errStub("ParseUintAlphabet", test.arg)
+		_, err := ParseUintAlphabet([]byte("0"), Base62, test.arg)
+		if !reflect.DeepEqual(testErr, err) {
+			t.Errorf("ParseUintAlphabet(\"0\", Base62, %v) = 0, %v want 0, %v",
+				test.arg, err, testErr)
+		}
+	}
+}
+
+func TestNewAlphabetPanics(t *testing.T) {
+	tests := []struct {
+		digits   string
+		foldCase bool
+		aliases  string
+	}{
+		{"", false, ""},
+		{"0", false, ""},
+		{"001", false, ""},
+		{"aA", true, ""},
+		{"01", false, "x"},
+		{"01", false, "x2"},
+		{"01", false, "1001"},
+	}
+	for _, test := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewAlphabet(%q, %v, %q) did not panic", test.digits, test.foldCase, test.aliases)
+				}
+			}()
+			NewAlphabet(test.digits, test.foldCase, test.aliases)
+		}()
+	}
+}
+
+func BenchmarkParseUintAlphabet(b *testing.B) {
+	ba := []byte("LygHa16AHYF")
+	for i := 0; i < b.N; i++ {
+		n, _ := ParseUintAlphabet(ba, Base62, 64)
+		BenchSink += int(n)
+	}
+}
+
+func BenchmarkAppendUintAlphabet(b *testing.B) {
+	dst := make([]byte, 0, 64)
+	for _, a := range []*Alphabet{Base62, Crockford32} {
+		b.Run(Itoba(a.Base()), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				dst = AppendUintAlphabet(dst[:0], 1<<64-1, a)
+				BenchSink += len(dst)
+			}
+		})
+	}
+}
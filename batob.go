@@ -4,47 +4,151 @@
 
 package baconv
 
-import "bytes"
-
-var trueValues = [][]byte{
-	[]byte{'1'},
-	[]byte{'t'},
-	[]byte{'T'},
-	[]byte{'t', 'r', 'u', 'e'},
-	[]byte{'T', 'r', 'u', 'e'},
-	[]byte{'T', 'R', 'U', 'E'}}
-
-var falseValues = [][]byte{
-	[]byte{'f', 'a', 'l', 's', 'e'},
-	[]byte{'0'},
-	[]byte{'f'},
-	[]byte{'F'},
-	[]byte{'F', 'a', 'l', 's', 'e'},
-	[]byte{'F', 'A', 'L', 'S', 'E'}}
+// A BoolParser parses boolean values from a fixed vocabulary of
+// true and false tokens. The zero value accepts nothing; use
+// NewBoolParser to build one. A BoolParser is safe for concurrent use.
+type BoolParser struct {
+	values   map[string]bool // token (lower-cased if foldCase) -> value
+	maxLen   int             // length of the longest token
+	foldCase bool            // match tokens case-insensitively
+}
+
+// The vocabulary of ParseBool.
+var (
+	stdTrueTokens  = []string{"1", "t", "T", "TRUE", "true", "True"}
+	stdFalseTokens = []string{"0", "f", "F", "FALSE", "false", "False"}
+)
+
+// DefaultBoolParser returns a new BoolParser with the vocabulary of
+// ParseBool: 1, t, T, TRUE, true and True are true and 0, f, F, FALSE,
+// false and False are false.
+func DefaultBoolParser() *BoolParser {
+	return NewBoolParser(stdTrueTokens, stdFalseTokens, false)
+}
+
+// NewBoolParser returns a BoolParser that accepts trueTokens as true and
+// falseTokens as false. If foldCase is set, tokens match regardless of
+// ASCII letter case. NewBoolParser panics if a token appears in both sets.
+func NewBoolParser(trueTokens, falseTokens []string, foldCase bool) *BoolParser {
+	p := &BoolParser{
+		values:   make(map[string]bool, len(trueTokens)+len(falseTokens)),
+		foldCase: foldCase,
+	}
+	p.add(trueTokens, true)
+	p.add(falseTokens, false)
+	return p
+}
+
+func (p *BoolParser) add(tokens []string, value bool) {
+	for _, token := range tokens {
+		if p.foldCase {
+			token = string(lowerASCII(make([]byte, 0, len(token)), []byte(token)))
+		}
+		if v, ok := p.values[token]; ok && v != value {
+			panic("bconv: token " + Quote(token) + " is both true and false")
+		}
+		p.values[token] = value
+		if len(token) > p.maxLen {
+			p.maxLen = len(token)
+		}
+	}
+}
+
+// Parse returns the boolean value represented by ba.
+// Any token outside the parser's vocabulary returns an error.
+func (p *BoolParser) Parse(ba []byte) (bool, error) {
+	const fnParseBool = "ParseBool"
+
+	if len(ba) > p.maxLen {
+		return false, syntaxError(fnParseBool, string(ba))
+	}
+	key := ba
+	if p.foldCase {
+		var buf [32]byte
+		key = lowerASCII(buf[:0], ba)
+	}
+	// The map lookup with a string(key) conversion does not allocate.
+	if v, ok := p.values[string(key)]; ok {
+		return v, nil
+	}
+	return false, syntaxError(fnParseBool, string(ba))
+}
+
+// lowerASCII appends ba with ASCII upper-case letters mapped to
+// lower case to dst and returns the extended buffer.
+func lowerASCII(dst, ba []byte) []byte {
+	for _, c := range ba {
+		if 'A' <= c && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		dst = append(dst, c)
+	}
+	return dst
+}
 
 // ParseBool returns the boolean value represented by the string.
 // It accepts 1, t, T, TRUE, true, True, 0, f, F, FALSE, false, False.
 // Any other value returns an error.
+// Use a BoolParser to accept other vocabularies.
 func ParseBool(ba []byte) (bool, error) {
-	for _, trueValue := range trueValues {
-		if bytes.Equal(trueValue, ba) {
-			return true, nil
-		}
+	const fnParseBool = "ParseBool"
+
+	if v, ok := parseStdBool(ba); ok {
+		return v, nil
 	}
-	for _, falseValue := range falseValues {
-		if bytes.Equal(falseValue, ba) {
-			return false, nil
+	return false, syntaxError(fnParseBool, string(ba))
+}
+
+// parseStdBool is a hand-written match of stdTrueTokens and
+// stdFalseTokens, the vocabulary of ParseBool.
+func parseStdBool(ba []byte) (value, ok bool) {
+	switch len(ba) {
+	case 1:
+		switch ba[0] {
+		case '1', 't', 'T':
+			return true, true
+		case '0', 'f', 'F':
+			return false, true
+		}
+	case 4:
+		switch ba[0] {
+		case 't':
+			if string(ba[1:]) == "rue" {
+				return true, true
+			}
+		case 'T':
+			if string(ba[1:]) == "rue" || string(ba[1:]) == "RUE" {
+				return true, true
+			}
+		}
+	case 5:
+		switch ba[0] {
+		case 'f':
+			if string(ba[1:]) == "alse" {
+				return false, true
+			}
+		case 'F':
+			if string(ba[1:]) == "alse" || string(ba[1:]) == "ALSE" {
+				return false, true
+			}
 		}
 	}
-	return false, syntaxError("ParseBool", string(ba))
+	return false, false
 }
 
+var (
+	trueBytes  = []byte("true")
+	falseBytes = []byte("false")
+)
+
 // FormatBool returns "true" or "false" according to the value of b.
+// The returned slice is shared and must not be modified; its capacity
+// equals its length, so appending to it always copies.
 func FormatBool(b bool) []byte {
 	if b {
-		return []byte("true")
+		return trueBytes[:len(trueBytes):len(trueBytes)]
 	}
-	return []byte("true")
+	return falseBytes[:len(falseBytes):len(falseBytes)]
 }
 
 // AppendBool appends "true" or "false", according to the value of b,
@@ -30,6 +30,14 @@ var atobtests = []atobTest{
 	{"TRUE", true, nil},
 	{"true", true, nil},
 	{"True", true, nil},
+	{"tRUE", false, ErrSyntax},
+	{"TrUe", false, ErrSyntax},
+	{"fALSE", false, ErrSyntax},
+	{"FaLsE", false, ErrSyntax},
+	{"truex", false, ErrSyntax},
+	{"fals", false, ErrSyntax},
+	{"2", false, ErrSyntax},
+	{"yes", false, ErrSyntax},
 }
 
 func TestParseBool(t *testing.T) {
@@ -56,15 +64,118 @@ func TestParseBool(t *testing.T) {
 	}
 }
 
+type boolParserTest struct {
+	foldCase bool
+	in       string
+	out      bool
+	err      error
+}
+
+var boolParserTests = []boolParserTest{
+	{false, "", false, ErrSyntax},
+	{false, "yes", true, nil},
+	{false, "on", true, nil},
+	{false, "enabled", true, nil},
+	{false, "no", false, nil},
+	{false, "off", false, nil},
+	{false, "disabled", false, nil},
+	{false, "YES", false, ErrSyntax},
+	{false, "Off", false, ErrSyntax},
+	{false, "true", false, ErrSyntax},
+	{false, "enabledx", false, ErrSyntax},
+	{true, "", false, ErrSyntax},
+	{true, "yes", true, nil},
+	{true, "YES", true, nil},
+	{true, "Y", true, nil},
+	{true, "EnAbLeD", true, nil},
+	{true, "N", false, nil},
+	{true, "Off", false, nil},
+	{true, "DISABLED", false, nil},
+	{true, "disable", false, ErrSyntax},
+	{true, "yess", false, ErrSyntax},
+}
+
+func TestBoolParser(t *testing.T) {
+	trueTokens := []string{"yes", "y", "on", "enabled"}
+	falseTokens := []string{"no", "n", "off", "disabled"}
+	parsers := map[bool]*BoolParser{
+		false: NewBoolParser(trueTokens, falseTokens, false),
+		true:  NewBoolParser(trueTokens, falseTokens, true),
+	}
+	for _, test := range boolParserTests {
+		b, e := parsers[test.foldCase].Parse([]byte(test.in))
+		if test.err != nil {
+			if e == nil {
+				t.Errorf("%s (foldCase=%v): expected %s but got nil", test.in, test.foldCase, test.err)
+			} else if test.err != e.(*NumError).Err {
+				t.Errorf("%s (foldCase=%v): expected %s but got %s", test.in, test.foldCase, test.err, e)
+			}
+		} else {
+			if e != nil {
+				t.Errorf("%s (foldCase=%v): expected no error but got %s", test.in, test.foldCase, e)
+			}
+			if b != test.out {
+				t.Errorf("%s (foldCase=%v): expected %t but got %t", test.in, test.foldCase, test.out, b)
+			}
+		}
+	}
+}
+
+func TestBoolParserConflict(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("NewBoolParser with conflicting tokens did not panic")
+		}
+	}()
+	NewBoolParser([]string{"on"}, []string{"ON"}, true)
+}
+
+// Verify that ParseBool matches the vocabulary of DefaultBoolParser.
+func TestDefaultBoolParser(t *testing.T) {
+	p := DefaultBoolParser()
+	inputs := []string{"TRUe", "Fals", "false ", "00", "1 "}
+	for _, test := range atobtests {
+		inputs = append(inputs, test.in)
+	}
+	for _, in := range inputs {
+		b, e := ParseBool([]byte(in))
+		wb, we := p.Parse([]byte(in))
+		if b != wb || (e == nil) != (we == nil) {
+			t.Errorf("ParseBool(%q) = %t, %v, want %t, %v", in, b, e, wb, we)
+		}
+	}
+}
+
 var boolString = map[bool]string{
 	true:  "true",
 	false: "false",
 }
 
-func TeststringFormatBool(t *testing.T) {
+func TestFormatBool(t *testing.T) {
+	for b, s := range boolString {
+		if f := string(FormatBool(b)); f != s {
+			t.Errorf(`FormatBool(%v): expected %q but got %q`, b, s, f)
+		}
+	}
+}
+
+func TestFormatBoolAppendCopies(t *testing.T) {
 	for b, s := range boolString {
+		ba := append(FormatBool(b), '!')
+		ba[0] = 'X'
 		if f := string(FormatBool(b)); f != s {
-			t.Errorf(`string(FormatBool(%v): expected %q but got %q`, b, s, f)
+			t.Errorf(`append to FormatBool(%v) modified the shared result: %q`, b, f)
+		}
+	}
+}
+
+func TestBoolRoundTrip(t *testing.T) {
+	for _, b := range []bool{true, false} {
+		if got, err := ParseBool(FormatBool(b)); err != nil || got != b {
+			t.Errorf("ParseBool(FormatBool(%v)) = %v, %v", b, got, err)
+		}
+		if got, err := ParseBool(AppendBool(nil, b)); err != nil || got != b {
+			t.Errorf("ParseBool(AppendBool(nil, %v)) = %v, %v", b, got, err)
 		}
 	}
 }
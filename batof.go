@@ -17,6 +17,36 @@ import (
 
 var optimize = true // can change for testing
 
+// ParseFloatOptions selects the forms of floating-point number that
+// ParseFloat accepts beyond the plain [-]digits[.digits][e[±]digits].
+// The zero value is strict and accepts none of them; ParseFloat allows
+// them all.
+type ParseFloatOptions struct {
+	// AllowSpecial accepts the special values "Inf", "+Inf", "-Inf",
+	// "Infinity" and "NaN", ignoring case.
+	AllowSpecial bool
+
+	// AllowPlus accepts a leading plus sign, as in "+1" and "+Inf".
+	// The sign of an exponent is not affected.
+	AllowPlus bool
+
+	// AllowLeadingDot accepts a number without digits before the
+	// decimal point, such as ".5".
+	AllowLeadingDot bool
+
+	// AllowTrailingDot accepts a number without digits after the
+	// decimal point, such as "5.".
+	AllowTrailingDot bool
+}
+
+// lenientFloat are the options of ParseFloat.
+var lenientFloat = ParseFloatOptions{
+	AllowSpecial:     true,
+	AllowPlus:        true,
+	AllowLeadingDot:  true,
+	AllowTrailingDot: true,
+}
+
 func equalIgnoreCase(s1, s2 string) bool {
 	if len(s1) != len(s2) {
 		return false
@@ -37,7 +67,7 @@ func equalIgnoreCase(s1, s2 string) bool {
 	return true
 }
 
-func (d *decimal) set(ba []byte) (ok bool) {
+func (d *decimal) set(ba []byte, opts *ParseFloatOptions) (ok bool) {
 	i := 0
 	d.neg = false
 	d.trunc = false
@@ -48,6 +78,9 @@ func (d *decimal) set(ba []byte) (ok bool) {
 	}
 	switch {
 	case ba[i] == '+':
+		if !opts.AllowPlus {
+			return
+		}
 		i++
 	case ba[i] == '-':
 		d.neg = true
@@ -60,7 +93,7 @@ func (d *decimal) set(ba []byte) (ok bool) {
 	for ; i < len(ba); i++ {
 		switch {
 		case ba[i] == '.':
-			if sawdot {
+			if sawdot || !sawdigits && !opts.AllowLeadingDot {
 				return
 			}
 			sawdot = true
@@ -83,7 +116,7 @@ func (d *decimal) set(ba []byte) (ok bool) {
 		}
 		break
 	}
-	if !sawdigits {
+	if !sawdigits || ba[i-1] == '.' && !opts.AllowTrailingDot {
 		return
 	}
 	if !sawdot {
@@ -129,8 +162,8 @@ func (d *decimal) set(ba []byte) (ok bool) {
 
 // readFloat reads a decimal mantissa and exponent from a float
 // string representation. It sets ok to false if the number could
-// not fit return types or is invalid.
-func readFloat(ba []byte) (mantissa uint64, exp int, neg, trunc, ok bool) {
+// not fit return types or is invalid, including by opts.
+func readFloat(ba []byte, opts *ParseFloatOptions) (mantissa uint64, exp int, neg, trunc, ok bool) {
 	const uint64digits = 19
 	i := 0
 
@@ -140,6 +173,9 @@ func readFloat(ba []byte) (mantissa uint64, exp int, neg, trunc, ok bool) {
 	}
 	switch {
 	case ba[i] == '+':
+		if !opts.AllowPlus {
+			return
+		}
 		i++
 	case ba[i] == '-':
 		neg = true
@@ -155,7 +191,7 @@ func readFloat(ba []byte) (mantissa uint64, exp int, neg, trunc, ok bool) {
 	for ; i < len(ba); i++ {
 		switch c := ba[i]; true {
 		case c == '.':
-			if sawdot {
+			if sawdot || !sawdigits && !opts.AllowLeadingDot {
 				return
 			}
 			sawdot = true
@@ -180,7 +216,7 @@ func readFloat(ba []byte) (mantissa uint64, exp int, neg, trunc, ok bool) {
 		}
 		break
 	}
-	if !sawdigits {
+	if !sawdigits || ba[i-1] == '.' && !opts.AllowTrailingDot {
 		return
 	}
 	if !sawdot {
@@ -421,14 +457,19 @@ var negInfinity = [][]byte{
 	[]byte{'-', 'i', 'n', 'f', 'i', 'n', 'i', 't', 'y'}}
 var nan = []byte{'n', 'a', 'n'}
 
-func special(ba []byte) (f float64, ok bool) {
-	if len(ba) == 0 {
+func special(ba []byte, opts *ParseFloatOptions) (f float64, ok bool) {
+	if len(ba) == 0 || !opts.AllowSpecial {
 		return
 	}
 	switch ba[0] {
 	default:
 		return
-	case '+', 'i', 'I':
+	case '+':
+		if !opts.AllowPlus {
+			return
+		}
+		fallthrough
+	case 'i', 'I':
 		for _, val := range infinity {
 			if bytes.EqualFold(ba, val) {
 				return math.Inf(1), true
@@ -449,13 +490,17 @@ func special(ba []byte) (f float64, ok bool) {
 }
 
 func Batof32(ba []byte) (f float32, err error) {
-	if val, ok := special(ba); ok {
+	return batof32(ba, &lenientFloat)
+}
+
+func batof32(ba []byte, opts *ParseFloatOptions) (f float32, err error) {
+	if val, ok := special(ba, opts); ok {
 		return float32(val), nil
 	}
 
 	if optimize {
 		// Parse mantissa and exponent.
-		mantissa, exp, neg, trunc, ok := readFloat(ba)
+		mantissa, exp, neg, trunc, ok := readFloat(ba, opts)
 		if ok {
 			// Try pure floating-point arithmetic conversion.
 			if !trunc {
@@ -476,7 +521,7 @@ func Batof32(ba []byte) (f float32, err error) {
 		}
 	}
 	var d decimal
-	if !d.set(ba) {
+	if !d.set(ba, opts) {
 		return 0, syntaxError(fnParseFloat, string(ba))
 	}
 	bits, ovf := d.floatBits(&float32info)
@@ -488,13 +533,17 @@ func Batof32(ba []byte) (f float32, err error) {
 }
 
 func Batof64(ba []byte) (f float64, err error) {
-	if val, ok := special(ba); ok {
+	return batof64(ba, &lenientFloat)
+}
+
+func batof64(ba []byte, opts *ParseFloatOptions) (f float64, err error) {
+	if val, ok := special(ba, opts); ok {
 		return val, nil
 	}
 
 	if optimize {
 		// Parse mantissa and exponent.
-		mantissa, exp, neg, trunc, ok := readFloat(ba)
+		mantissa, exp, neg, trunc, ok := readFloat(ba, opts)
 		if ok {
 			// Try pure floating-point arithmetic conversion.
 			if !trunc {
@@ -515,7 +564,7 @@ func Batof64(ba []byte) (f float64, err error) {
 		}
 	}
 	var d decimal
-	if !d.set(ba) {
+	if !d.set(ba, opts) {
 		return 0, syntaxError(fnParseFloat, string(ba))
 	}
 	bits, ovf := d.floatBits(&float64info)
@@ -544,9 +593,19 @@ func Batof64(ba []byte) (f float64, err error) {
 // away from the largest floating point number of the given size,
 // ParseFloat returns f = ±Inf, err.Err = ErrRange.
 func ParseFloat(ba []byte, bitSize int) (float64, error) {
+	return parseFloat(ba, bitSize, &lenientFloat)
+}
+
+// ParseFloat is like the ParseFloat function, but accepts only the
+// forms of number that o allows.
+func (o ParseFloatOptions) ParseFloat(ba []byte, bitSize int) (float64, error) {
+	return parseFloat(ba, bitSize, &o)
+}
+
+func parseFloat(ba []byte, bitSize int, opts *ParseFloatOptions) (float64, error) {
 	if bitSize == 32 {
-		f, err := Batof32(ba)
+		f, err := batof32(ba, opts)
 		return float64(f), err
 	}
-	return Batof64(ba)
+	return batof64(ba, opts)
 }
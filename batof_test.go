@@ -325,6 +325,70 @@ func TestBatofRandom(t *testing.T) {
 	t.Logf("tested %d random numbers", len(batofRandomTests))
 }
 
+func TestParseFloatOptions(t *testing.T) {
+	all := ParseFloatOptions{AllowSpecial: true, AllowPlus: true, AllowLeadingDot: true, AllowTrailingDot: true}
+	tests := []struct {
+		in   string
+		opts ParseFloatOptions
+		out  string
+		err  error
+	}{
+		{"1.5", ParseFloatOptions{}, "1.5", nil},
+		{"-0.5e+3", ParseFloatOptions{}, "-500", nil},
+		{"1e-2", ParseFloatOptions{}, "0.01", nil},
+		{"inf", ParseFloatOptions{}, "0", ErrSyntax},
+		{"-Inf", ParseFloatOptions{}, "0", ErrSyntax},
+		{"NaN", ParseFloatOptions{}, "0", ErrSyntax},
+		{"infinity", ParseFloatOptions{AllowSpecial: true}, "+Inf", nil},
+		{"-Inf", ParseFloatOptions{AllowSpecial: true}, "-Inf", nil},
+		{"nan", ParseFloatOptions{AllowSpecial: true}, "NaN", nil},
+		{"+Inf", ParseFloatOptions{AllowSpecial: true}, "0", ErrSyntax},
+		{"+Inf", ParseFloatOptions{AllowSpecial: true, AllowPlus: true}, "+Inf", nil},
+		{"+1", ParseFloatOptions{}, "0", ErrSyntax},
+		{"+1", ParseFloatOptions{AllowPlus: true}, "1", nil},
+		{"+.5", ParseFloatOptions{AllowPlus: true}, "0", ErrSyntax},
+		{".5", ParseFloatOptions{}, "0", ErrSyntax},
+		{"-.5", ParseFloatOptions{}, "0", ErrSyntax},
+		{".5", ParseFloatOptions{AllowLeadingDot: true}, "0.5", nil},
+		{"+.5", all, "0.5", nil},
+		{"5.", ParseFloatOptions{}, "0", ErrSyntax},
+		{"5.e3", ParseFloatOptions{}, "0", ErrSyntax},
+		{"5.", ParseFloatOptions{AllowTrailingDot: true}, "5", nil},
+		{"5.e3", ParseFloatOptions{AllowTrailingDot: true}, "5000", nil},
+		{".", all, "0", ErrSyntax},
+		{"1" + strings.Repeat("0", 800) + ".", ParseFloatOptions{}, "0", ErrSyntax},
+		{"." + strings.Repeat("0", 800) + "1", ParseFloatOptions{}, "0", ErrSyntax},
+		{"1e+400", ParseFloatOptions{}, "+Inf", ErrRange},
+	}
+	for _, opt := range []bool{true, false} {
+		oldopt := SetOptimize(opt)
+		for _, tt := range tests {
+			for _, bitSize := range []int{32, 64} {
+				out, err := tt.opts.ParseFloat([]byte(tt.in), bitSize)
+				if err != nil {
+					err = err.(*NumError).Err
+				}
+				outs := string(FormatFloat(out, 'g', -1, bitSize))
+				if outs != tt.out || err != tt.err {
+					t.Errorf("%+v.ParseFloat(%q, %d) = %v, %v, want %v, %v",
+						tt.opts, tt.in, bitSize, outs, err, tt.out, tt.err)
+				}
+			}
+		}
+		SetOptimize(oldopt)
+	}
+
+	// With every option, the forms accepted are those of ParseFloat.
+	for _, tt := range batoftests {
+		out, err := all.ParseFloat([]byte(tt.in), 64)
+		want, werr := ParseFloat([]byte(tt.in), 64)
+		if math.Float64bits(out) != math.Float64bits(want) && !(math.IsNaN(out) && math.IsNaN(want)) ||
+			!reflect.DeepEqual(err, werr) {
+			t.Errorf("%+v.ParseFloat(%q, 64) = %v, %v, want %v, %v", all, tt.in, out, err, want, werr)
+		}
+	}
+}
+
 var roundTripCases = []struct {
 	f float64
 	s string
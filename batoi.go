@@ -4,7 +4,10 @@
 
 package baconv
 
-import "errors"
+import (
+	"encoding/binary"
+	"errors"
+)
 
 // ErrRange indicates that a value is out of range for the target type.
 var ErrRange = errors.New("value out of range")
@@ -85,53 +88,113 @@ func ParseUint(ba []byte, base int, bitSize int) (uint64, error) {
 		return 0, bitSizeError(fnParseUint, string(ba0), bitSize)
 	}
 
-	// Cutoff is the smallest number such that cutoff*base > maxUint64.
-	// Use compile-time constants for common cases.
-	var cutoff uint64
-	switch base {
-	case 10:
-		cutoff = maxUint64/10 + 1
-	case 16:
-		cutoff = maxUint64/16 + 1
-	default:
-		cutoff = maxUint64/uint64(base) + 1
-	}
-
 	maxVal := uint64(1)<<uint(bitSize) - 1
 
 	var n uint64
-	for _, c := range []byte(ba) {
-		var d byte
-		switch {
-		case '0' <= c && c <= '9':
-			d = c - '0'
-		case 'a' <= c && c <= 'z':
-			d = c - 'a' + 10
-		case 'A' <= c && c <= 'Z':
-			d = c - 'A' + 10
-		default:
-			return 0, syntaxError(fnParseUint, string(ba0))
+	if base == 10 {
+		// Fast path: convert 8 digits at a time for as long as
+		// the result is guaranteed to fit in a uint64
+		// (16 digits < 20 digits of maxUint64).
+		// Anything else, including invalid input, is left to the
+		// generic loop below so that errors are reported the same way.
+		for nd := 0; nd+8 <= 16 && len(ba) >= 8; nd += 8 {
+			chunk := binary.LittleEndian.Uint64(ba)
+			if !isEightDigits(chunk) {
+				break
+			}
+			n = n*1e8 + uint64(parseEightDigits(chunk))
+			if n > maxVal {
+				return maxVal, rangeError(fnParseUint, string(ba0))
+			}
+			ba = ba[8:]
 		}
+	}
 
+	cutoff := uintCutoff(base)
+	for _, c := range []byte(ba) {
+		d := base36.values[c]
 		if d >= byte(base) {
+			// not a digit, or not valid in base
 			return 0, syntaxError(fnParseUint, string(ba0))
 		}
-
-		if n >= cutoff {
-			// n*base overflows
+		var ok bool
+		if n, ok = addDigit(n, d, base, cutoff, maxVal); !ok {
 			return maxVal, rangeError(fnParseUint, string(ba0))
 		}
-		n *= uint64(base)
+	}
 
-		n1 := n + uint64(d)
-		if n1 < n || n1 > maxVal {
-			// n+v overflows
-			return maxVal, rangeError(fnParseUint, string(ba0))
+	return n, nil
+}
+
+// uintCutoff returns the smallest number such that cutoff*base > maxUint64.
+func uintCutoff(base int) uint64 {
+	// Use compile-time constants for common cases.
+	switch base {
+	case 10:
+		return maxUint64/10 + 1
+	case 16:
+		return maxUint64/16 + 1
+	}
+	return maxUint64/uint64(base) + 1
+}
+
+// addDigit returns n*base + d. It reports false if the result exceeds
+// maxVal or overflows a uint64; cutoff is uintCutoff(base).
+func addDigit(n uint64, d byte, base int, cutoff, maxVal uint64) (uint64, bool) {
+	if n >= cutoff {
+		// n*base overflows
+		return maxVal, false
+	}
+	n *= uint64(base)
+
+	n1 := n + uint64(d)
+	if n1 < n || n1 > maxVal {
+		// n+v overflows
+		return maxVal, false
+	}
+	return n1, true
+}
+
+// leadingUint consumes the leading decimal digits of ba using the same
+// overflow checks as ParseUint. It returns their value, the number of
+// bytes consumed and whether the value overflowed a uint64, in which
+// case n is maxUint64 and the remaining digits are still consumed.
+func leadingUint(ba []byte) (n uint64, i int, overflow bool) {
+	const cutoff = maxUint64/10 + 1
+	for ; i < len(ba); i++ {
+		c := ba[i]
+		if c < '0' || c > '9' {
+			break
+		}
+		if overflow {
+			continue
 		}
-		n = n1
+		var ok bool
+		n, ok = addDigit(n, c-'0', 10, cutoff, maxUint64)
+		overflow = !ok
 	}
+	return
+}
 
-	return n, nil
+// isEightDigits reports whether all eight bytes packed little-endian
+// in chunk are ASCII decimal digits.
+func isEightDigits(chunk uint64) bool {
+	// Every digit has high nibble 3; adding 6 to each byte keeps
+	// the high nibble at 3 only for '0' to '9'.
+	return (chunk&0xF0F0F0F0F0F0F0F0)|
+		((chunk+0x0606060606060606)&0xF0F0F0F0F0F0F0F0)>>4 == 0x3333333333333333
+}
+
+// parseEightDigits converts eight ASCII decimal digits packed
+// little-endian in chunk (first digit in the lowest byte) to their value.
+// It combines neighbouring digits pairwise: 1-digit lanes into 2-digit
+// lanes, those into 4-digit lanes and finally into a single 8-digit value.
+func parseEightDigits(chunk uint64) uint32 {
+	chunk -= 0x3030303030303030
+	chunk = (chunk * (10<<8 + 1)) >> 8
+	chunk = ((chunk & 0x00FF00FF00FF00FF) * (100<<16 + 1)) >> 16
+	chunk = ((chunk & 0x0000FFFF0000FFFF) * (10000<<32 + 1)) >> 32
+	return uint32(chunk)
 }
 
 // ParseInt interprets a string s in the given base (0, 2 to 36) and
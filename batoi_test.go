@@ -28,6 +28,17 @@ var parseUint64Tests = []parseUint64Test{
 	{"18446744073709551615", 1<<64 - 1, nil},
 	{"18446744073709551616", 1<<64 - 1, ErrRange},
 	{"18446744073709551620", 1<<64 - 1, ErrRange},
+
+	// chunks of 8 digits
+	{"12345678", 12345678, nil},
+	{"1234567x", 0, ErrSyntax},
+	{"12345678x", 0, ErrSyntax},
+	{"1234567812345678", 1234567812345678, nil},
+	{"123456781234567x", 0, ErrSyntax},
+	{"1234567/1234567:", 0, ErrSyntax},
+	{"00000000000000000000000000000001", 1, nil},
+	{"99999999999999999999", 1<<64 - 1, ErrRange},
+	{"99999999999999999999x", 1<<64 - 1, ErrRange},
 }
 
 type parseUint64BaseTest struct {
@@ -161,6 +172,9 @@ var parseUint32Tests = []parseUint32Test{
 	{"987654321", 987654321, nil},
 	{"4294967295", 1<<32 - 1, nil},
 	{"4294967296", 1<<32 - 1, ErrRange},
+	{"0000000004294967295", 1<<32 - 1, nil},
+	{"1234567812345678", 1<<32 - 1, ErrRange},
+	{"42949672960000x0", 1<<32 - 1, ErrRange},
 }
 
 type parseInt32Test struct {
@@ -543,3 +557,21 @@ func benchmarkBatoi(b *testing.B, neg int) {
 		})
 	}
 }
+
+func BenchmarkParseUintDigits(b *testing.B) {
+	cases := []string{
+		"7",
+		"12345678",
+		"1234567812345678",
+		"1234567812345678123",
+	}
+	for _, cs := range cases {
+		ba := []byte(cs)
+		b.Run(Itoba(len(cs)), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				out, _ := ParseUint(ba, 10, 64)
+				BenchSink += int(out)
+			}
+		})
+	}
+}
@@ -8,11 +8,15 @@ import (
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 )
 
 var (
-	globalBuf [64]byte
-	nextToOne = "1.00000000000000011102230246251565404236316680908203125" + strings.Repeat("0", 10000) + "1"
+	globalBuf  [64]byte
+	globalTime = time.Date(2006, 1, 2, 15, 4, 5, 999999999, time.FixedZone("", 7*3600))
+	nextToOne  = "1.00000000000000011102230246251565404236316680908203125" + strings.Repeat("0", 10000) + "1"
+
+	foldingBoolParser = NewBoolParser([]string{"yes", "enabled"}, []string{"no", "disabled"}, true)
 
 	mallocTest = []struct {
 		count int
@@ -24,13 +28,42 @@ var (
 			AppendInt(localBuf[:0], 123, 10)
 		}},
 		{0, `AppendInt(globalBuf[:0], 123, 10)`, func() { AppendInt(globalBuf[:0], 123, 10) }},
+		{0, `AppendInt(globalBuf[:0], -9223372036854775808, 10)`, func() { AppendInt(globalBuf[:0], -1<<63, 10) }},
+		{0, `AppendUint(globalBuf[:0], 18446744073709551615, 10)`, func() { AppendUint(globalBuf[:0], 1<<64-1, 10) }},
 		{0, `AppendFloat(localBuf[:0], 1.23, 'g', 5, 64)`, func() {
 			var localBuf [64]byte
 			AppendFloat(localBuf[:0], 1.23, 'g', 5, 64)
 		}},
 		{0, `AppendFloat(globalBuf[:0], 1.23, 'g', 5, 64)`, func() { AppendFloat(globalBuf[:0], 1.23, 'g', 5, 64) }},
+		{0, `ParseBool("true")`, func() { ParseBool([]byte("true")) }},
+		{0, `ParseBool("FALSE")`, func() { ParseBool([]byte("FALSE")) }},
+		{0, `FormatBool(true)`, func() { FormatBool(true) }},
+		{0, `FormatBool(false)`, func() { FormatBool(false) }},
+		{0, `foldingBoolParser.Parse("Enabled")`, func() { foldingBoolParser.Parse([]byte("Enabled")) }},
+		{0, `ParseDuration("1h30m15.5s")`, func() { ParseDuration([]byte("1h30m15.5s")) }},
+		{0, `AppendDuration(globalBuf[:0], 1h30m15.5s)`, func() { AppendDuration(globalBuf[:0], 5415500000000) }},
+		{0, `ParseRFC3339("2006-01-02T15:04:05.999Z")`, func() { ParseRFC3339([]byte("2006-01-02T15:04:05.999Z")) }},
+		{0, `ParseRFC3339("2006-01-02T15:04:05+05:45")`, func() { ParseRFC3339([]byte("2006-01-02T15:04:05+05:45")) }},
+		{0, `AppendRFC3339(globalBuf[:0], t, 9)`, func() { AppendRFC3339(globalBuf[:0], globalTime, 9) }},
+		{0, `ParseUnixTime("1700000000.123456", 0)`, func() { ParseUnixTime([]byte("1700000000.123456"), 0) }},
+		{0, `AppendUnixTime(globalBuf[:0], t, time.Millisecond)`, func() { AppendUnixTime(globalBuf[:0], globalTime, time.Millisecond) }},
+		{0, `AppendHex64(globalBuf[:0], 1<<64-1)`, func() { AppendHex64(globalBuf[:0], 1<<64-1) }},
+		{0, `ParseHex64("0123456789abcdef")`, func() { ParseHex64([]byte("0123456789abcdef")) }},
+		{0, `AppendQuoteJSON(globalBuf[:0], "<a href=\"x\">", true)`, func() {
+			AppendQuoteJSON(globalBuf[:0], []byte(`<a href="x">`), true)
+		}},
+		{0, `UnquoteJSON("\"Hello, world\"")`, func() { UnquoteJSON([]byte(`"Hello, world"`)) }},
+		{0, `AppendQuoteCSV(globalBuf[:0], "a,\"b\"", ',')`, func() { AppendQuoteCSV(globalBuf[:0], []byte(`a,"b"`), ',') }},
+		{0, `UnquoteCSV("\"a,b\"")`, func() { UnquoteCSV([]byte(`"a,b"`)) }},
+		{0, `AppendQuoteShell(globalBuf[:0], "it's")`, func() { AppendQuoteShell(globalBuf[:0], []byte("it's")) }},
+		{0, `AppendQuoteSQL(globalBuf[:0], "O'Brien", SQLStandard)`, func() { AppendQuoteSQL(globalBuf[:0], []byte("O'Brien"), SQLStandard) }},
+		{0, `UnquoteSQL("'Hello, world'", SQLPostgres)`, func() { UnquoteSQL([]byte("'Hello, world'"), SQLPostgres) }},
+		{0, `QuotedPrefix("\"a\\tb\\u263a\" rest")`, func() { QuotedPrefix([]byte(`"a\tb\u263a" rest`)) }},
 		{0, `ParseFloat("123.45", 64)`, func() { ParseFloat([]byte("123.45"), 64) }},
 		{0, `ParseFloat("123.456789123456789", 64)`, func() { ParseFloat([]byte("123.456789123456789"), 64) }},
+		{0, `ParseFloatOptions{}.ParseFloat("123.45", 64)`, func() { ParseFloatOptions{}.ParseFloat([]byte("123.45"), 64) }},
+		{0, `ParseJSONNumber("-123.456e-7")`, func() { ParseJSONNumber([]byte("-123.456e-7")) }},
+		{0, `ClassifyJSONNumber("18446744073709551615")`, func() { ClassifyJSONNumber([]byte("18446744073709551615")) }},
 		{0, `ParseFloat("1.000000000000000111022302462515654042363166809082031251", 64)`, func() {
 			ParseFloat([]byte("1.000000000000000111022302462515654042363166809082031251"), 64)
 		}},
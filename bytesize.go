@@ -0,0 +1,170 @@
+package baconv
+
+import "math/bits"
+
+// A ByteSizeStyle selects the unit prefixes used by AppendByteSize.
+type ByteSizeStyle int
+
+const (
+	ByteSizeSI  ByteSizeStyle = iota // powers of 1000: kB, MB, GB, ...
+	ByteSizeIEC                      // powers of 1024: KiB, MiB, GiB, ...
+)
+
+const byteSizePrefixes = "kMGTPE"
+
+// byteSizeUnit returns the number of bytes in the unit u, such as
+// "MB", "MiB", "m" or "" (bytes). The prefix letter and a trailing B
+// are matched regardless of case; an i after the prefix selects the
+// binary (IEC) unit.
+func byteSizeUnit(u []byte) (mult uint64, ok bool) {
+	if len(u) > 0 && (u[len(u)-1] == 'B' || u[len(u)-1] == 'b') {
+		u = u[:len(u)-1]
+	}
+	if len(u) == 0 {
+		return 1, true
+	}
+	k := 0
+	for ; k < len(byteSizePrefixes); k++ {
+		if lower(u[0]) == lower(byteSizePrefixes[k]) {
+			break
+		}
+	}
+	if k == len(byteSizePrefixes) {
+		return 0, false
+	}
+	k++
+	switch {
+	case len(u) == 1:
+		mult = 1
+		for ; k > 0; k-- {
+			mult *= 1000
+		}
+		return mult, true
+	case len(u) == 2 && lower(u[1]) == 'i':
+		return 1 << (10 * uint(k)), true
+	}
+	return 0, false
+}
+
+func lower(c byte) byte {
+	return c | ('x' - 'X')
+}
+
+// ParseByteSize interprets ba as a size in bytes, such as "512MiB",
+// "1.5GB", "100 kB" or "4096". The number is a non-negative decimal
+// with optional fraction. It may be followed, optionally after a space,
+// by an SI unit (k, M, G, T, P, E: powers of 1000) or an IEC unit
+// (Ki, Mi, Gi, Ti, Pi, Ei: powers of 1024), each optionally followed
+// by B. Units are matched regardless of case.
+//
+// The conversion is exact, without floating-point rounding; a
+// fractional number of bytes is truncated, so "1.1KiB" is 1126.
+//
+// The errors that ParseByteSize returns have concrete type *NumError
+// and include err.Num = s. If s is syntactically invalid,
+// err.Err = ErrSyntax and the returned value is 0; if the size does
+// not fit a uint64, err.Err = ErrRange and the returned value is
+// maxUint64.
+func ParseByteSize(ba []byte) (uint64, error) {
+	const fnParseByteSize = "ParseByteSize"
+
+	// The number is scanned separately from the unit,
+	// so that E is never mistaken for an exponent.
+	whole, i, overflow := leadingUint(ba)
+	frac := ba[i:i]
+	if i < len(ba) && ba[i] == '.' {
+		j := i + 1
+		for j < len(ba) && '0' <= ba[j] && ba[j] <= '9' {
+			j++
+		}
+		frac = ba[i+1 : j]
+		i = j
+	}
+	if i == 0 || i == 1 && ba[0] == '.' {
+		return 0, syntaxError(fnParseByteSize, string(ba))
+	}
+	unit := ba[i:]
+	if len(unit) > 0 && unit[0] == ' ' {
+		unit = unit[1:]
+		if len(unit) == 0 {
+			return 0, syntaxError(fnParseByteSize, string(ba))
+		}
+	}
+	mult, ok := byteSizeUnit(unit)
+	if !ok {
+		return 0, syntaxError(fnParseByteSize, string(ba))
+	}
+
+	hi, n := bits.Mul64(whole, mult)
+	if overflow || hi != 0 {
+		return maxUint64, rangeError(fnParseByteSize, string(ba))
+	}
+
+	// Add floor(0.frac * mult), evaluated exactly by Horner's rule from
+	// the last digit: floor((d*mult + floor(x))/10) == floor((d*mult + x)/10).
+	// x < mult <= 1<<60, so d*mult + x never overflows.
+	var x uint64
+	for j := len(frac) - 1; j >= 0; j-- {
+		x = (uint64(frac[j]-'0')*mult + x) / 10
+	}
+	n1 := n + x
+	if n1 < n {
+		return maxUint64, rangeError(fnParseByteSize, string(ba))
+	}
+	return n1, nil
+}
+
+// AppendByteSize appends the string form of the size n in bytes to dst
+// and returns the extended buffer. It uses the largest unit of the given
+// style in which n can be written exactly with at most three fractional
+// digits, such as "1.5KiB", "512MiB", "1.25GB" or "1023B".
+// ParseByteSize parses the result back to n.
+func AppendByteSize(dst []byte, n uint64, style ByteSizeStyle) []byte {
+	var base uint64
+	switch style {
+	case ByteSizeSI:
+		base = 1000
+	case ByteSizeIEC:
+		base = 1024
+	default:
+		panic("bconv: illegal AppendByteSize style")
+	}
+
+	// Find the largest unit that represents n exactly with
+	// at most three fractional digits.
+	k, mult := 0, uint64(1)
+	frac := uint64(0)
+	for u, j := base, 1; n >= u; u, j = u*base, j+1 {
+		// r*1000/u < 1000, so the quotient fits and Div64 cannot panic.
+		hi, lo := bits.Mul64(n%u, 1000)
+		f, rem := bits.Div64(hi, lo, u)
+		if rem == 0 {
+			k, mult, frac = j, u, f
+		}
+		if j == len(byteSizePrefixes) {
+			break
+		}
+	}
+
+	dst = AppendUint(dst, n/mult, 10)
+	if frac != 0 {
+		var a [4]byte
+		a[0] = '.'
+		a[1] = byte(frac/100) + '0'
+		a[2] = byte(frac/10%10) + '0'
+		a[3] = byte(frac%10) + '0'
+		w := len(a)
+		for a[w-1] == '0' {
+			w--
+		}
+		dst = append(dst, a[:w]...)
+	}
+	if k > 0 {
+		if style == ByteSizeIEC {
+			dst = append(dst, byteSizePrefixes[k-1]&^('x'-'X'), 'i')
+		} else {
+			dst = append(dst, byteSizePrefixes[k-1])
+		}
+	}
+	return append(dst, 'B')
+}
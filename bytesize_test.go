@@ -0,0 +1,124 @@
+package baconv
+
+import "testing"
+
+type parseByteSizeTest struct {
+	in  string
+	out uint64
+	err error
+}
+
+var parseByteSizeTests = []parseByteSizeTest{
+	{"0", 0, nil},
+	{"4096", 4096, nil},
+	{"4096B", 4096, nil},
+	{"4096 B", 4096, nil},
+	{"1k", 1000, nil},
+	{"1kB", 1000, nil},
+	{"1KB", 1000, nil},
+	{"1Ki", 1024, nil},
+	{"1KiB", 1024, nil},
+	{"1kib", 1024, nil},
+	{"512MiB", 512 << 20, nil},
+	{"512 MiB", 512 << 20, nil},
+	{"1.5GB", 1500000000, nil},
+	{"1.5GiB", 3 << 29, nil},
+	{"1.1KiB", 1126, nil},
+	{".5kB", 500, nil},
+	{"5.kB", 5000, nil},
+	{"0.001kB", 1, nil},
+	{"1.9999B", 1, nil},
+	{"0.0001kB", 0, nil},
+	{"2TB", 2e12, nil},
+	{"2TiB", 2 << 40, nil},
+	{"3PB", 3e15, nil},
+	{"3PiB", 3 << 50, nil},
+	{"1EB", 1e18, nil},
+	{"1EiB", 1 << 60, nil},
+	{"15.99999999999999999EiB", 1<<64 - 12, nil},
+	{"0.99999999999999999999999999999KiB", 1023, nil},
+	{"1023.99999999999999999999999999999KiB", 1<<20 - 1, nil},
+	{"18446744073709551615", 1<<64 - 1, nil},
+	{"18446744073709551615.9", 1<<64 - 1, nil},
+	{"18.446744073709551615EB", 1<<64 - 1, nil},
+	{"0.00000000000000000000000000001EiB", 0, nil},
+	{"1000000000000000000000000000000e", 1<<64 - 1, ErrRange},
+
+	{"", 0, ErrSyntax},
+	{"B", 0, ErrSyntax},
+	{"kB", 0, ErrSyntax},
+	{".", 0, ErrSyntax},
+	{"1..5kB", 0, ErrSyntax},
+	{"-1kB", 0, ErrSyntax},
+	{"+1kB", 0, ErrSyntax},
+	{"1e3", 0, ErrSyntax},
+	{"1 ", 0, ErrSyntax},
+	{"1  kB", 0, ErrSyntax},
+	{"1kBB", 0, ErrSyntax},
+	{"1xB", 0, ErrSyntax},
+	{"1iB", 0, ErrSyntax},
+	{"1KiBi", 0, ErrSyntax},
+	{"16EiB", 1<<64 - 1, ErrRange},
+	{"18446744073709551616", 1<<64 - 1, ErrRange},
+	{"18446744073709551616.0", 1<<64 - 1, ErrRange},
+	{"18.446744073709551616EB", 1<<64 - 1, ErrRange},
+	{"1.000000000000000000001kB", 1000, nil},
+}
+
+func TestParseByteSize(t *testing.T) {
+	for _, test := range parseByteSizeTests {
+		out, err := ParseByteSize([]byte(test.in))
+		if test.err != nil {
+			if err == nil {
+				t.Errorf("ParseByteSize(%q) = %v, want error %v", test.in, out, test.err)
+			} else if err.(*NumError).Err != test.err || out != test.out {
+				t.Errorf("ParseByteSize(%q) = %v, %v, want %v, %v", test.in, out, err, test.out, test.err)
+			}
+			continue
+		}
+		if err != nil || out != test.out {
+			t.Errorf("ParseByteSize(%q) = %v, %v, want %v, nil", test.in, out, err, test.out)
+		}
+	}
+}
+
+type appendByteSizeTest struct {
+	in    uint64
+	style ByteSizeStyle
+	out   string
+}
+
+var appendByteSizeTests = []appendByteSizeTest{
+	{0, ByteSizeSI, "0B"},
+	{999, ByteSizeSI, "999B"},
+	{1000, ByteSizeSI, "1kB"},
+	{1500, ByteSizeSI, "1.5kB"},
+	{1001, ByteSizeSI, "1.001kB"},
+	{1234567, ByteSizeSI, "1234.567kB"},
+	{1500000000, ByteSizeSI, "1.5GB"},
+	{1250000000000, ByteSizeSI, "1.25TB"},
+	{1e18, ByteSizeSI, "1EB"},
+	{1<<64 - 1, ByteSizeSI, "18446744073709551.615kB"},
+	{1<<64 - 1616, ByteSizeSI, "18446744073709.55MB"},
+	{0, ByteSizeIEC, "0B"},
+	{1023, ByteSizeIEC, "1023B"},
+	{1024, ByteSizeIEC, "1KiB"},
+	{1025, ByteSizeIEC, "1025B"},
+	{1536, ByteSizeIEC, "1.5KiB"},
+	{512 << 20, ByteSizeIEC, "512MiB"},
+	{5 << 28, ByteSizeIEC, "1.25GiB"},
+	{1 << 60, ByteSizeIEC, "1EiB"},
+	{1<<64 - 1<<60, ByteSizeIEC, "15EiB"},
+}
+
+func TestAppendByteSize(t *testing.T) {
+	for _, test := range appendByteSizeTests {
+		out := AppendByteSize([]byte("n="), test.in, test.style)
+		if string(out) != "n="+test.out {
+			t.Errorf("AppendByteSize(%v, %v) = %q, want %q", test.in, test.style, out, "n="+test.out)
+		}
+		if back, err := ParseByteSize(out[2:]); err != nil || back != test.in {
+			t.Errorf("ParseByteSize(%q) = %v, %v, want %v", out[2:], back, err, test.in)
+		}
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright 2010 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package baconv
+
+import "time"
+
+// leadingFraction consumes the leading decimal digits of ba.
+// It is used only for fractions, so it does not report overflow;
+// it just stops accumulating precision.
+func leadingFraction(ba []byte) (x uint64, scale float64, i int) {
+	scale = 1
+	overflow := false
+	for ; i < len(ba); i++ {
+		c := ba[i]
+		if c < '0' || c > '9' {
+			break
+		}
+		if overflow {
+			continue
+		}
+		if x > (1<<63-1)/10 {
+			// It's possible for overflow to give a positive number, so take care.
+			overflow = true
+			continue
+		}
+		y := x*10 + uint64(c-'0')
+		if y > 1<<63 {
+			overflow = true
+			continue
+		}
+		x = y
+		scale *= 10
+	}
+	return
+}
+
+// durationUnit returns the length in nanoseconds of the duration unit u.
+func durationUnit(u []byte) (unit uint64, ok bool) {
+	switch string(u) {
+	case "ns":
+		return uint64(time.Nanosecond), true
+	case "us", "µs", "μs": // U+00B5 = micro symbol, U+03BC = Greek letter mu
+		return uint64(time.Microsecond), true
+	case "ms":
+		return uint64(time.Millisecond), true
+	case "s":
+		return uint64(time.Second), true
+	case "m":
+		return uint64(time.Minute), true
+	case "h":
+		return uint64(time.Hour), true
+	}
+	return 0, false
+}
+
+// ParseDuration parses a duration in the syntax accepted by
+// time.ParseDuration: a possibly signed sequence of decimal numbers,
+// each with optional fraction and a unit suffix, such as "300ms",
+// "-1.5h" or "2h45m". Valid time units are "ns", "us" (or "µs"), "ms",
+// "s", "m", "h".
+//
+// The errors that ParseDuration returns have concrete type *NumError
+// and include err.Num = s. If s is not a valid duration,
+// err.Err = ErrSyntax; if it is out of the range of time.Duration,
+// err.Err = ErrRange. In both cases the returned duration is 0.
+func ParseDuration(ba []byte) (time.Duration, error) {
+	const fnParseDuration = "ParseDuration"
+
+	// [-+]?([0-9]*(\.[0-9]*)?[a-z]+)+
+	ba0 := ba
+	var d uint64
+	neg := false
+
+	// Consume [-+]?
+	if len(ba) > 0 {
+		c := ba[0]
+		if c == '-' || c == '+' {
+			neg = c == '-'
+			ba = ba[1:]
+		}
+	}
+	// Special case: if all that is left is "0", this is zero.
+	if len(ba) == 1 && ba[0] == '0' {
+		return 0, nil
+	}
+	if len(ba) == 0 {
+		return 0, syntaxError(fnParseDuration, string(ba0))
+	}
+	for len(ba) > 0 {
+		var (
+			f     uint64      // integer after decimal point
+			scale float64 = 1 // value = v + f/scale
+		)
+
+		// The next character must be [0-9.]
+		if !(ba[0] == '.' || '0' <= ba[0] && ba[0] <= '9') {
+			return 0, syntaxError(fnParseDuration, string(ba0))
+		}
+		// Consume [0-9]*
+		v, n, overflow := leadingUint(ba)
+		if overflow || v > 1<<63 {
+			return 0, rangeError(fnParseDuration, string(ba0))
+		}
+		ba = ba[n:]
+		pre := n > 0 // whether we consumed anything before a period
+
+		// Consume (\.[0-9]*)?
+		post := false
+		if len(ba) > 0 && ba[0] == '.' {
+			ba = ba[1:]
+			f, scale, n = leadingFraction(ba)
+			ba = ba[n:]
+			post = n > 0
+		}
+		if !pre && !post {
+			// no digits (e.g. ".s" or "-.s")
+			return 0, syntaxError(fnParseDuration, string(ba0))
+		}
+
+		// Consume unit.
+		i := 0
+		for ; i < len(ba); i++ {
+			c := ba[i]
+			if c == '.' || '0' <= c && c <= '9' {
+				break
+			}
+		}
+		unit, ok := durationUnit(ba[:i])
+		if !ok {
+			// missing or unknown unit
+			return 0, syntaxError(fnParseDuration, string(ba0))
+		}
+		ba = ba[i:]
+		if v > 1<<63/unit {
+			return 0, rangeError(fnParseDuration, string(ba0))
+		}
+		v *= unit
+		if f > 0 {
+			// float64 is needed to be nanosecond accurate for fractions of hours.
+			// v >= 0 && (f*unit/scale) <= 3.6e+12 (ns/h, h is the largest unit)
+			v += uint64(float64(f) * (float64(unit) / scale))
+			if v > 1<<63 {
+				return 0, rangeError(fnParseDuration, string(ba0))
+			}
+		}
+		d += v
+		if d > 1<<63 {
+			return 0, rangeError(fnParseDuration, string(ba0))
+		}
+	}
+	if neg {
+		return -time.Duration(d), nil
+	}
+	if d > 1<<63-1 {
+		return 0, rangeError(fnParseDuration, string(ba0))
+	}
+	return time.Duration(d), nil
+}
+
+// AppendDuration appends the string form of d, as generated by
+// d.String(), to dst and returns the extended buffer. For example,
+// 72h3m0.5s. Durations less than one second use a smaller unit
+// (milli-, micro-, or nanoseconds) to ensure that the leading digit
+// is non-zero. The zero duration formats as 0s.
+func AppendDuration(dst []byte, d time.Duration) []byte {
+	// Largest time is 2540400h10m10.000000000s
+	var a [32]byte
+	i := len(a)
+
+	u := uint64(d)
+	neg := d < 0
+	if neg {
+		u = -u
+	}
+
+	if u < uint64(time.Second) {
+		// Special case: if duration is smaller than a second,
+		// use smaller units, like 1.2ms
+		var prec int
+		i--
+		a[i] = 's'
+		i--
+		switch {
+		case u == 0:
+			a[i] = '0'
+			return append(dst, a[i:]...)
+		case u < uint64(time.Microsecond):
+			// print nanoseconds
+			prec = 0
+			a[i] = 'n'
+		case u < uint64(time.Millisecond):
+			// print microseconds
+			prec = 3
+			// U+00B5 'µ' micro sign == 0xC2 0xB5
+			i-- // Need room for two bytes.
+			copy(a[i:], "µ")
+		default:
+			// print milliseconds
+			prec = 6
+			a[i] = 'm'
+		}
+		i, u = fmtFrac(a[:i], u, prec)
+		i = fmtInt(a[:i], u)
+	} else {
+		i--
+		a[i] = 's'
+
+		i, u = fmtFrac(a[:i], u, 9)
+
+		// u is now integer seconds
+		i = fmtInt(a[:i], u%60)
+		u /= 60
+
+		// u is now integer minutes
+		if u > 0 {
+			i--
+			a[i] = 'm'
+			i = fmtInt(a[:i], u%60)
+			u /= 60
+
+			// u is now integer hours
+			// Stop at hours because days can be different lengths.
+			if u > 0 {
+				i--
+				a[i] = 'h'
+				i = fmtInt(a[:i], u)
+			}
+		}
+	}
+
+	if neg {
+		i--
+		a[i] = '-'
+	}
+
+	return append(dst, a[i:]...)
+}
+
+// fmtFrac formats the fraction of v/10**prec (e.g., ".12345") into the
+// tail of buf, omitting trailing zeros. It omits the decimal
+// point too when the fraction is 0. It returns the index where the
+// output bytes begin and the value v/10**prec.
+func fmtFrac(buf []byte, v uint64, prec int) (nw int, nv uint64) {
+	// Omit trailing zeros up to and including decimal point.
+	w := len(buf)
+	print := false
+	for i := 0; i < prec; i++ {
+		digit := v % 10
+		print = print || digit != 0
+		if print {
+			w--
+			buf[w] = byte(digit) + '0'
+		}
+		v /= 10
+	}
+	if print {
+		w--
+		buf[w] = '.'
+	}
+	return w, v
+}
+
+// fmtInt formats v into the tail of buf.
+// It returns the index where the output begins.
+func fmtInt(buf []byte, v uint64) int {
+	w := len(buf)
+	if v == 0 {
+		w--
+		buf[w] = '0'
+	} else {
+		for v > 0 {
+			w--
+			buf[w] = byte(v%10) + '0'
+			v /= 10
+		}
+	}
+	return w
+}
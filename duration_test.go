@@ -0,0 +1,192 @@
+// Copyright 2010 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package baconv
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+type parseDurationTest struct {
+	in  string
+	out time.Duration
+	err error
+}
+
+var parseDurationTests = []parseDurationTest{
+	// simple
+	{"0", 0, nil},
+	{"5s", 5 * time.Second, nil},
+	{"30s", 30 * time.Second, nil},
+	{"1478s", 1478 * time.Second, nil},
+	// sign
+	{"-5s", -5 * time.Second, nil},
+	{"+5s", 5 * time.Second, nil},
+	{"-0", 0, nil},
+	{"+0", 0, nil},
+	// decimal
+	{"5.0s", 5 * time.Second, nil},
+	{"5.6s", 5*time.Second + 600*time.Millisecond, nil},
+	{"5.s", 5 * time.Second, nil},
+	{".5s", 500 * time.Millisecond, nil},
+	{"1.0s", 1 * time.Second, nil},
+	{"1.00s", 1 * time.Second, nil},
+	{"1.004s", 1*time.Second + 4*time.Millisecond, nil},
+	{"1.0040s", 1*time.Second + 4*time.Millisecond, nil},
+	{"100.00100s", 100*time.Second + 1*time.Millisecond, nil},
+	// different units
+	{"10ns", 10 * time.Nanosecond, nil},
+	{"11us", 11 * time.Microsecond, nil},
+	{"12µs", 12 * time.Microsecond, nil}, // U+00B5
+	{"12μs", 12 * time.Microsecond, nil}, // U+03BC
+	{"13ms", 13 * time.Millisecond, nil},
+	{"14s", 14 * time.Second, nil},
+	{"15m", 15 * time.Minute, nil},
+	{"16h", 16 * time.Hour, nil},
+	// composite durations
+	{"3h30m", 3*time.Hour + 30*time.Minute, nil},
+	{"1h30m15.5s", time.Hour + 30*time.Minute + 15*time.Second + 500*time.Millisecond, nil},
+	{"10.5s4m", 4*time.Minute + 10*time.Second + 500*time.Millisecond, nil},
+	{"-2m3.4s", -(2*time.Minute + 3*time.Second + 400*time.Millisecond), nil},
+	{"1h2m3s4ms5us6ns", 1*time.Hour + 2*time.Minute + 3*time.Second + 4*time.Millisecond + 5*time.Microsecond + 6*time.Nanosecond, nil},
+	{"39h9m14.425s", 39*time.Hour + 9*time.Minute + 14*time.Second + 425*time.Millisecond, nil},
+	// large value
+	{"52763797000ns", 52763797000 * time.Nanosecond, nil},
+	// more than 9 digits after decimal point, see https://golang.org/issue/6617
+	{"0.3333333333333333333h", 20 * time.Minute, nil},
+	// 9007199254740993 = 1<<53+1 cannot be stored precisely in a float64
+	{"9007199254740993ns", (1<<53 + 1) * time.Nanosecond, nil},
+	// largest duration that can be represented by int64 in nanoseconds
+	{"9223372036854775807ns", (1<<63 - 1) * time.Nanosecond, nil},
+	{"9223372036854775.807us", (1<<63 - 1) * time.Nanosecond, nil},
+	{"9223372036s854ms775us807ns", (1<<63 - 1) * time.Nanosecond, nil},
+	{"-9223372036854775808ns", -1 << 63 * time.Nanosecond, nil},
+	{"-9223372036854775.808us", -1 << 63 * time.Nanosecond, nil},
+	{"-9223372036s854ms775us808ns", -1 << 63 * time.Nanosecond, nil},
+	// huge string; issue 15011.
+	{"0.100000000000000000000h", 6 * time.Minute, nil},
+	// This value tests the first overflow check in leadingFraction.
+	{"0.830103483285477580700h", 49*time.Minute + 48*time.Second + 372539827*time.Nanosecond, nil},
+
+	// errors
+	{"", 0, ErrSyntax},
+	{"3", 0, ErrSyntax},
+	{"-", 0, ErrSyntax},
+	{"s", 0, ErrSyntax},
+	{".", 0, ErrSyntax},
+	{"-.", 0, ErrSyntax},
+	{".s", 0, ErrSyntax},
+	{"+.s", 0, ErrSyntax},
+	{"1d", 0, ErrSyntax},
+	{"1.2.3s", 0, ErrSyntax},
+	{"1sx", 0, ErrSyntax},
+	{"\x85\x85", 0, ErrSyntax},
+	{"\xffff", 0, ErrSyntax},
+	{"hello \xffff world", 0, ErrSyntax},
+	{"9223372036854775808ns", 0, ErrRange},
+	{"9223372036854775.808us", 0, ErrRange},
+	{"9223372036854ms775us808ns", 0, ErrRange},
+	{"-9223372036854775809ns", 0, ErrRange},
+	{"18446744073709551616ns", 0, ErrRange},
+	{"3000000h", 0, ErrRange},
+}
+
+func TestParseDuration(t *testing.T) {
+	for _, test := range parseDurationTests {
+		d, err := ParseDuration([]byte(test.in))
+		if test.err != nil {
+			if err == nil {
+				t.Errorf("ParseDuration(%q) = %v, want error %v", test.in, d, test.err)
+			} else if err.(*NumError).Err != test.err {
+				t.Errorf("ParseDuration(%q): error %v, want %v", test.in, err, test.err)
+			}
+			continue
+		}
+		if err != nil || d != test.out {
+			t.Errorf("ParseDuration(%q) = %v, %v, want %v, nil", test.in, d, err, test.out)
+		}
+	}
+}
+
+var durationTests = []time.Duration{
+	0,
+	1 * time.Nanosecond,
+	1100 * time.Nanosecond,
+	2200 * time.Microsecond,
+	3300 * time.Millisecond,
+	4*time.Minute + 5*time.Second,
+	4*time.Minute + 5001*time.Millisecond,
+	5*time.Hour + 6*time.Minute + 7001*time.Millisecond,
+	8*time.Minute + 1*time.Nanosecond,
+	1<<63 - 1,
+	-1 << 63,
+	-1 * time.Nanosecond,
+	-4*time.Minute - 5*time.Second,
+}
+
+func TestAppendDuration(t *testing.T) {
+	for _, d := range durationTests {
+		if got, want := string(AppendDuration([]byte("d="), d)), "d="+d.String(); got != want {
+			t.Errorf("AppendDuration(%d) = %q, want %q", int64(d), got, want)
+		}
+	}
+}
+
+func FuzzParseDuration(f *testing.F) {
+	for _, test := range parseDurationTests {
+		f.Add(test.in)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		d, err := ParseDuration([]byte(s))
+		want, wantErr := time.ParseDuration(s)
+		if (err != nil) != (wantErr != nil) {
+			t.Fatalf("ParseDuration(%q) = %v, %v; time.ParseDuration = %v, %v", s, d, err, want, wantErr)
+		}
+		if err != nil {
+			if nerr := err.(*NumError); nerr.Func != "ParseDuration" || nerr.Num != s {
+				t.Fatalf("ParseDuration(%q): bad error %#v", s, nerr)
+			}
+			return
+		}
+		if d != want {
+			t.Fatalf("ParseDuration(%q) = %v, want %v", s, d, want)
+		}
+	})
+}
+
+func FuzzAppendDuration(f *testing.F) {
+	for _, d := range durationTests {
+		f.Add(int64(d))
+	}
+	f.Add(int64(math.MaxInt64 / 3))
+	f.Fuzz(func(t *testing.T, i int64) {
+		d := time.Duration(i)
+		ba := AppendDuration(nil, d)
+		if string(ba) != d.String() {
+			t.Fatalf("AppendDuration(%d) = %q, want %q", i, ba, d.String())
+		}
+		if back, err := ParseDuration(ba); err != nil || back != d {
+			t.Fatalf("ParseDuration(%q) = %v, %v, want %v", ba, back, err, d)
+		}
+	})
+}
+
+func BenchmarkParseDuration(b *testing.B) {
+	ba := []byte("1h30m15.5s")
+	for i := 0; i < b.N; i++ {
+		d, _ := ParseDuration(ba)
+		BenchSink += int(d)
+	}
+}
+
+func BenchmarkAppendDuration(b *testing.B) {
+	dst := make([]byte, 0, 32)
+	d := time.Hour + 30*time.Minute + 15500*time.Millisecond
+	for i := 0; i < b.N; i++ {
+		dst = AppendDuration(dst[:0], d)
+		BenchSink += len(dst)
+	}
+}
@@ -46,6 +46,19 @@ func ExampleAppendInt() {
 	// int (base 16):-2a
 }
 
+func ExampleAppendPadded() {
+	for _, name := range []string{"Go", "世界"} {
+		b := []byte("|")
+		b = AppendPadded(b, []byte(name), -6)
+		b = append(b, '|')
+		fmt.Println(string(b))
+	}
+
+	// Output:
+	// |Go    |
+	// |世界  |
+}
+
 func ExampleAppendQuote() {
 	b := []byte("quote:")
 	b = AppendQuote(b, `"Fran & Freddie's Diner"`)
@@ -55,6 +68,29 @@ func ExampleAppendQuote() {
 	// quote:"\"Fran & Freddie's Diner\""
 }
 
+func ExampleAppendQuoteCSV() {
+	var b []byte
+	for i, field := range []string{"plain", "a,b", `say "hi"`} {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = AppendQuoteCSV(b, []byte(field), ',')
+	}
+	fmt.Println(string(b))
+
+	// Output:
+	// plain,"a,b","say ""hi"""
+}
+
+func ExampleAppendQuoteJSON() {
+	b := []byte("json:")
+	b = AppendQuoteJSON(b, []byte("<b>\tbold\u2028</b>"), true)
+	fmt.Println(string(b))
+
+	// Output:
+	// json:"\u003cb\u003e\tbold\u2028\u003c/b\u003e"
+}
+
 func ExampleAppendQuoteRune() {
 	b := []byte("rune:")
 	b = AppendQuoteRune(b, '☺')
@@ -73,6 +109,18 @@ func ExampleAppendQuoteRuneToASCII() {
 	// rune (ascii):'\u263a'
 }
 
+func ExampleAppendQuoteShell() {
+	b := []byte("echo")
+	for _, arg := range []string{"-n", "it's", "$HOME"} {
+		b = append(b, ' ')
+		b = AppendQuoteShell(b, []byte(arg))
+	}
+	fmt.Println(string(b))
+
+	// Output:
+	// echo -n 'it'\''s' '$HOME'
+}
+
 func ExampleAppendQuoteToASCII() {
 	b := []byte("quote (ascii):")
 	b = AppendQuoteToASCII(b, `"Fran & Freddie's Diner"`)
@@ -96,6 +144,20 @@ func ExampleAppendUint() {
 	// uint (base 16):2a
 }
 
+func ExampleAppendUintAlphabet() {
+	b62 := []byte("uint (base 62):")
+	b62 = AppendUintAlphabet(b62, 1234567890, Base62)
+	fmt.Println(string(b62))
+
+	b32 := []byte("uint (Crockford base 32):")
+	b32 = AppendUintAlphabet(b32, 1234567890, Crockford32)
+	fmt.Println(string(b32))
+
+	// Output:
+	// uint (base 62):1LY7VK
+	// uint (Crockford base 32):14SC0PJ
+}
+
 func ExampleBatoi() {
 	v := "10"
 	if s, err := Batoi([]byte(v)); err == nil {
@@ -106,6 +168,19 @@ func ExampleBatoi() {
 	// int, 10
 }
 
+func ExampleBoolParser() {
+	p := NewBoolParser([]string{"yes", "on"}, []string{"no", "off"}, true)
+	for _, v := range []string{"Yes", "OFF", "maybe"} {
+		b, err := p.Parse([]byte(v))
+		fmt.Println(b, err)
+	}
+
+	// Output:
+	// true <nil>
+	// false <nil>
+	// false bconv.ParseBool: parsing "maybe": invalid syntax
+}
+
 func ExampleCanBackquote() {
 	fmt.Println(CanBackquote("Fran & Freddie's Diner ☺"))
 	fmt.Println(CanBackquote("`can't backquote this`"))
@@ -115,7 +190,29 @@ func ExampleCanBackquote() {
 	// false
 }
 
-func ExamplestringFormatBool() {
+func ExampleClassifyJSONNumber() {
+	for _, v := range []string{"-42", "18446744073709551615", "1e3", "01"} {
+		switch kind, i, u, err := ClassifyJSONNumber([]byte(v)); {
+		case err != nil:
+			fmt.Println(err)
+		case kind == NumberInt:
+			fmt.Printf("int64 %v\n", i)
+		case kind == NumberUint:
+			fmt.Printf("uint64 %v\n", u)
+		default:
+			f, _ := ParseJSONNumber([]byte(v))
+			fmt.Printf("float64 %v\n", f)
+		}
+	}
+
+	// Output:
+	// int64 -42
+	// uint64 18446744073709551615
+	// float64 1000
+	// bconv.ClassifyJSONNumber: parsing "01": invalid syntax
+}
+
+func ExampleFormatBool() {
 	v := true
 	s := string(FormatBool(v))
 	fmt.Printf("%T, %v\n", s, s)
@@ -227,6 +324,24 @@ func ExampleParseFloat() {
 	// float64, 3.1415926535
 }
 
+func ExampleParseFloatOptions() {
+	strict := ParseFloatOptions{}
+	for _, v := range []string{"12.5", "+12.5", ".5", "5.", "NaN"} {
+		if f, err := strict.ParseFloat([]byte(v), 64); err == nil {
+			fmt.Printf("%T, %v\n", f, f)
+		} else {
+			fmt.Println(err)
+		}
+	}
+
+	// Output:
+	// float64, 12.5
+	// bconv.ParseFloat: parsing "+12.5": invalid syntax
+	// bconv.ParseFloat: parsing ".5": invalid syntax
+	// bconv.ParseFloat: parsing "5.": invalid syntax
+	// bconv.ParseFloat: parsing "NaN": invalid syntax
+}
+
 func ExampleParseInt() {
 	v32 := "-354634382"
 	if s, err := ParseInt([]byte(v32), 10, 32); err == nil {
@@ -271,6 +386,22 @@ func ExampleQuote() {
 	// "\"Fran & Freddie's Diner\t☺\""
 }
 
+func ExampleQuoteC() {
+	s := QuoteC("\x1b[0m??!")
+	fmt.Println(s)
+
+	// Output:
+	// "\x1b[0m?\?!"
+}
+
+func ExampleQuoteConfig() {
+	c := QuoteConfig{Delim: '\'', Escape: NewEscapeSet("|")}
+	fmt.Println(c.Quote("it's a|b"))
+
+	// Output:
+	// 'it\'s a\x7cb'
+}
+
 func ExampleQuoteRune() {
 	s := QuoteRune('☺')
 	fmt.Println(s)
@@ -331,6 +462,14 @@ func ExampleQuoteToGraphic() {
 	// "\" This is a ☺ \\n \""
 }
 
+func ExampleQuotedPrefix() {
+	lit, err := QuotedPrefix([]byte(`"abc\"def" rest`))
+	fmt.Printf("%s, %v\n", lit, err)
+
+	// Output:
+	// "abc\"def", <nil>
+}
+
 func ExampleUnquote() {
 	s, err := Unquote("You can't unquote a string without quotes")
 	fmt.Printf("%q, %v\n", s, err)
@@ -351,6 +490,17 @@ func ExampleUnquote() {
 	// "", invalid syntax
 }
 
+func ExampleUnquoteBrace() {
+	s, err := UnquoteBrace(`"\u{1F600} \u{e9}t\u{e9}"`)
+	fmt.Printf("%q, %v\n", s, err)
+	s, err = UnquoteBrace(`"\u{D83D}"`)
+	fmt.Printf("%q, %v\n", s, err)
+
+	// Output:
+	// "😀 été", <nil>
+	// "", invalid syntax
+}
+
 func ExampleUnquoteChar() {
 	v, mb, t, err := UnquoteChar(`\"Fran & Freddie's Diner\"`, '"')
 	if err != nil {
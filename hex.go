@@ -0,0 +1,112 @@
+package baconv
+
+const upperhex = "0123456789ABCDEF"
+
+// appendHexFixed appends u as exactly width hex digits, zero padded,
+// to dst. u must fit in width digits.
+func appendHexFixed(dst []byte, u uint64, width int, hexDigits string) []byte {
+	var a [16]byte
+	i := formatPow2(a[:width], u, hexDigits, width)
+	return append(dst, a[i:width]...)
+}
+
+// parseHexFixed parses exactly width hex digits of either case.
+func parseHexFixed(fn string, ba []byte, width int) (uint64, error) {
+	if len(ba) != width {
+		return 0, syntaxError(fn, string(ba))
+	}
+	var n uint64
+	for _, c := range ba {
+		d := base36.values[c]
+		if d >= 16 {
+			return 0, syntaxError(fn, string(ba))
+		}
+		n = n<<4 | uint64(d)
+	}
+	return n, nil
+}
+
+// AppendHex16 appends x as exactly 4 lower-case hex digits to dst
+// and returns the extended buffer.
+func AppendHex16(dst []byte, x uint16) []byte {
+	return appendHexFixed(dst, uint64(x), 4, lowerhex)
+}
+
+// AppendHex16Upper is like AppendHex16 but uses upper-case hex digits.
+func AppendHex16Upper(dst []byte, x uint16) []byte {
+	return appendHexFixed(dst, uint64(x), 4, upperhex)
+}
+
+// AppendHex32 appends x as exactly 8 lower-case hex digits to dst
+// and returns the extended buffer.
+func AppendHex32(dst []byte, x uint32) []byte {
+	return appendHexFixed(dst, uint64(x), 8, lowerhex)
+}
+
+// AppendHex32Upper is like AppendHex32 but uses upper-case hex digits.
+func AppendHex32Upper(dst []byte, x uint32) []byte {
+	return appendHexFixed(dst, uint64(x), 8, upperhex)
+}
+
+// AppendHex64 appends x as exactly 16 lower-case hex digits to dst
+// and returns the extended buffer.
+func AppendHex64(dst []byte, x uint64) []byte {
+	return appendHexFixed(dst, x, 16, lowerhex)
+}
+
+// AppendHex64Upper is like AppendHex64 but uses upper-case hex digits.
+func AppendHex64Upper(dst []byte, x uint64) []byte {
+	return appendHexFixed(dst, x, 16, upperhex)
+}
+
+// AppendHex128 appends the 128-bit value hi<<64 | lo as exactly 32
+// lower-case hex digits to dst and returns the extended buffer.
+func AppendHex128(dst []byte, hi, lo uint64) []byte {
+	dst = appendHexFixed(dst, hi, 16, lowerhex)
+	return appendHexFixed(dst, lo, 16, lowerhex)
+}
+
+// AppendHex128Upper is like AppendHex128 but uses upper-case hex digits.
+func AppendHex128Upper(dst []byte, hi, lo uint64) []byte {
+	dst = appendHexFixed(dst, hi, 16, upperhex)
+	return appendHexFixed(dst, lo, 16, upperhex)
+}
+
+// ParseHex16 interprets ba as exactly 4 hex digits, of either case,
+// as written by AppendHex16 and AppendHex16Upper. There is no sign,
+// prefix or length tolerance: any other input returns an error with
+// concrete type *NumError and err.Err = ErrSyntax.
+func ParseHex16(ba []byte) (uint16, error) {
+	n, err := parseHexFixed("ParseHex16", ba, 4)
+	return uint16(n), err
+}
+
+// ParseHex32 is like ParseHex16 but for exactly 8 hex digits.
+func ParseHex32(ba []byte) (uint32, error) {
+	n, err := parseHexFixed("ParseHex32", ba, 8)
+	return uint32(n), err
+}
+
+// ParseHex64 is like ParseHex16 but for exactly 16 hex digits.
+func ParseHex64(ba []byte) (uint64, error) {
+	return parseHexFixed("ParseHex64", ba, 16)
+}
+
+// ParseHex128 is like ParseHex16 but for exactly 32 hex digits. It
+// returns the high and low 64 bits of the value.
+func ParseHex128(ba []byte) (hi, lo uint64, err error) {
+	const fnParseHex128 = "ParseHex128"
+
+	if len(ba) != 32 {
+		return 0, 0, syntaxError(fnParseHex128, string(ba))
+	}
+	if hi, err = parseHexFixed(fnParseHex128, ba[:16], 16); err != nil {
+		err.(*NumError).Num = string(ba)
+		return 0, 0, err
+	}
+	if lo, err = parseHexFixed(fnParseHex128, ba[16:], 16); err != nil {
+		err.(*NumError).Num = string(ba)
+		return 0, 0, err
+	}
+	return hi, lo, nil
+}
@@ -0,0 +1,134 @@
+package baconv
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type hexTest struct {
+	bits   int
+	hi, lo uint64
+	out    string
+}
+
+var hexTests = []hexTest{
+	{16, 0, 0, "0000"},
+	{16, 0, 0xab, "00ab"},
+	{16, 0, 0xffff, "ffff"},
+	{32, 0, 0, "00000000"},
+	{32, 0, 0xdeadbeef, "deadbeef"},
+	{32, 0, 0x1f, "0000001f"},
+	{64, 0, 0, "0000000000000000"},
+	{64, 0, 0x123456789abcdef, "0123456789abcdef"},
+	{64, 0, 1<<64 - 1, "ffffffffffffffff"},
+	{128, 0, 0, "00000000000000000000000000000000"},
+	{128, 1, 0, "00000000000000010000000000000000"},
+	{128, 0xfedcba9876543210, 0x123456789abcdef, "fedcba98765432100123456789abcdef"},
+}
+
+func appendHex(dst []byte, test hexTest, upper bool) []byte {
+	switch {
+	case test.bits == 16 && upper:
+		return AppendHex16Upper(dst, uint16(test.lo))
+	case test.bits == 16:
+		return AppendHex16(dst, uint16(test.lo))
+	case test.bits == 32 && upper:
+		return AppendHex32Upper(dst, uint32(test.lo))
+	case test.bits == 32:
+		return AppendHex32(dst, uint32(test.lo))
+	case test.bits == 64 && upper:
+		return AppendHex64Upper(dst, test.lo)
+	case test.bits == 64:
+		return AppendHex64(dst, test.lo)
+	case upper:
+		return AppendHex128Upper(dst, test.hi, test.lo)
+	}
+	return AppendHex128(dst, test.hi, test.lo)
+}
+
+func parseHex(ba []byte, bits int) (hi, lo uint64, err error) {
+	switch bits {
+	case 16:
+		n, err := ParseHex16(ba)
+		return 0, uint64(n), err
+	case 32:
+		n, err := ParseHex32(ba)
+		return 0, uint64(n), err
+	case 64:
+		n, err := ParseHex64(ba)
+		return 0, n, err
+	}
+	return ParseHex128(ba)
+}
+
+func TestHex(t *testing.T) {
+	for _, test := range hexTests {
+		for _, upper := range []bool{false, true} {
+			want := test.out
+			if upper {
+				want = strings.ToUpper(want)
+			}
+			out := appendHex([]byte("x="), test, upper)
+			if string(out) != "x="+want {
+				t.Errorf("AppendHex%d(%#x, %#x) upper=%v = %q, want %q", test.bits, test.hi, test.lo, upper, out, "x="+want)
+			}
+			hi, lo, err := parseHex(out[2:], test.bits)
+			if err != nil || hi != test.hi || lo != test.lo {
+				t.Errorf("ParseHex%d(%q) = %#x, %#x, %v, want %#x, %#x", test.bits, out[2:], hi, lo, err, test.hi, test.lo)
+			}
+		}
+	}
+}
+
+type parseHexErrorTest struct {
+	bits int
+	in   string
+}
+
+var parseHexErrorTests = []parseHexErrorTest{
+	{16, ""},
+	{16, "abc"},
+	{16, "abcde"},
+	{16, "0x12"},
+	{16, "-123"},
+	{16, "+123"},
+	{16, "12g4"},
+	{16, " 123"},
+	{32, "1234567"},
+	{32, "123456789"},
+	{32, "1234567z"},
+	{64, "ffffffffffffffff0"},
+	{64, "fffffffffffffff"},
+	{64, "ffffffff ffffffff"},
+	{128, "0000000000000000000000000000000"},
+	{128, "000000000000000000000000000000000"},
+	{128, "000000000000000g0000000000000000"},
+	{128, "0000000000000000000000000000000g"},
+}
+
+func TestParseHexErrors(t *testing.T) {
+	for _, test := range parseHexErrorTests {
+		hi, lo, err := parseHex([]byte(test.in), test.bits)
+		want := &NumError{"ParseHex" + Itoba(test.bits), test.in, ErrSyntax}
+		if hi != 0 || lo != 0 || !reflect.DeepEqual(err, want) {
+			t.Errorf("ParseHex%d(%q) = %#x, %#x, %v, want 0, 0, %v", test.bits, test.in, hi, lo, err, want)
+		}
+	}
+}
+
+func BenchmarkAppendHex64(b *testing.B) {
+	dst := make([]byte, 0, 16)
+	for i := 0; i < b.N; i++ {
+		dst = AppendHex64(dst[:0], uint64(i))
+		BenchSink += len(dst)
+	}
+}
+
+func BenchmarkParseHex64(b *testing.B) {
+	ba := []byte("0123456789abcdef")
+	for i := 0; i < b.N; i++ {
+		n, _ := ParseHex64(ba)
+		BenchSink += int(n)
+	}
+}
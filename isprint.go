@@ -6,7 +6,10 @@
 
 package baconv
 
-// (456+140+86)*2 + (396)*4 = 2948 bytes
+// unicodeVersion is the Unicode version the tables were generated from.
+const unicodeVersion = "17.0.0"
+
+// (412+132+120)*2 + (566)*4 = 3592 bytes
 
 var isPrint16 = []uint16{
 	0x0020, 0x007e,
@@ -16,17 +19,16 @@ var isPrint16 = []uint16{
 	0x0559, 0x058a,
 	0x058d, 0x05c7,
 	0x05d0, 0x05ea,
-	0x05f0, 0x05f4,
-	0x0606, 0x061b,
-	0x061e, 0x070d,
+	0x05ef, 0x05f4,
+	0x0606, 0x070d,
 	0x0710, 0x074a,
 	0x074d, 0x07b1,
 	0x07c0, 0x07fa,
-	0x0800, 0x082d,
+	0x07fd, 0x082d,
 	0x0830, 0x085b,
 	0x085e, 0x086a,
-	0x08a0, 0x08bd,
-	0x08d4, 0x098c,
+	0x0870, 0x088f,
+	0x0897, 0x098c,
 	0x098f, 0x0990,
 	0x0993, 0x09b2,
 	0x09b6, 0x09b9,
@@ -35,7 +37,7 @@ var isPrint16 = []uint16{
 	0x09cb, 0x09ce,
 	0x09d7, 0x09d7,
 	0x09dc, 0x09e3,
-	0x09e6, 0x09fd,
+	0x09e6, 0x09fe,
 	0x0a01, 0x0a0a,
 	0x0a0f, 0x0a10,
 	0x0a13, 0x0a39,
@@ -44,7 +46,7 @@ var isPrint16 = []uint16{
 	0x0a4b, 0x0a4d,
 	0x0a51, 0x0a51,
 	0x0a59, 0x0a5e,
-	0x0a66, 0x0a75,
+	0x0a66, 0x0a76,
 	0x0a81, 0x0ab9,
 	0x0abc, 0x0acd,
 	0x0ad0, 0x0ad0,
@@ -56,7 +58,7 @@ var isPrint16 = []uint16{
 	0x0b3c, 0x0b44,
 	0x0b47, 0x0b48,
 	0x0b4b, 0x0b4d,
-	0x0b56, 0x0b57,
+	0x0b55, 0x0b57,
 	0x0b5c, 0x0b63,
 	0x0b66, 0x0b77,
 	0x0b82, 0x0b8a,
@@ -71,19 +73,18 @@ var isPrint16 = []uint16{
 	0x0bd7, 0x0bd7,
 	0x0be6, 0x0bfa,
 	0x0c00, 0x0c39,
-	0x0c3d, 0x0c4d,
-	0x0c55, 0x0c5a,
+	0x0c3c, 0x0c4d,
+	0x0c55, 0x0c5d,
 	0x0c60, 0x0c63,
 	0x0c66, 0x0c6f,
-	0x0c78, 0x0cb9,
+	0x0c77, 0x0cb9,
 	0x0cbc, 0x0ccd,
 	0x0cd5, 0x0cd6,
-	0x0cde, 0x0ce3,
-	0x0ce6, 0x0cf2,
+	0x0cdc, 0x0ce3,
+	0x0ce6, 0x0cf3,
 	0x0d00, 0x0d4f,
 	0x0d54, 0x0d63,
-	0x0d66, 0x0d7f,
-	0x0d82, 0x0d96,
+	0x0d66, 0x0d96,
 	0x0d9a, 0x0dbd,
 	0x0dc0, 0x0dc6,
 	0x0dca, 0x0dca,
@@ -92,13 +93,8 @@ var isPrint16 = []uint16{
 	0x0df2, 0x0df4,
 	0x0e01, 0x0e3a,
 	0x0e3f, 0x0e5b,
-	0x0e81, 0x0e84,
-	0x0e87, 0x0e8a,
-	0x0e8d, 0x0e8d,
-	0x0e94, 0x0ea7,
-	0x0eaa, 0x0ebd,
-	0x0ec0, 0x0ecd,
-	0x0ed0, 0x0ed9,
+	0x0e81, 0x0ebd,
+	0x0ec0, 0x0ed9,
 	0x0edc, 0x0edf,
 	0x0f00, 0x0f6c,
 	0x0f71, 0x0fda,
@@ -117,16 +113,15 @@ var isPrint16 = []uint16{
 	0x13f8, 0x13fd,
 	0x1400, 0x169c,
 	0x16a0, 0x16f8,
-	0x1700, 0x1714,
-	0x1720, 0x1736,
+	0x1700, 0x1715,
+	0x171f, 0x1736,
 	0x1740, 0x1753,
 	0x1760, 0x1773,
 	0x1780, 0x17dd,
 	0x17e0, 0x17e9,
 	0x17f0, 0x17f9,
-	0x1800, 0x180d,
-	0x1810, 0x1819,
-	0x1820, 0x1877,
+	0x1800, 0x1819,
+	0x1820, 0x1878,
 	0x1880, 0x18aa,
 	0x18b0, 0x18f5,
 	0x1900, 0x192b,
@@ -142,15 +137,15 @@ var isPrint16 = []uint16{
 	0x1a7f, 0x1a89,
 	0x1a90, 0x1a99,
 	0x1aa0, 0x1aad,
-	0x1ab0, 0x1abe,
-	0x1b00, 0x1b4b,
-	0x1b50, 0x1b7c,
-	0x1b80, 0x1bf3,
+	0x1ab0, 0x1add,
+	0x1ae0, 0x1aeb,
+	0x1b00, 0x1bf3,
 	0x1bfc, 0x1c37,
 	0x1c3b, 0x1c49,
-	0x1c4d, 0x1c88,
-	0x1cc0, 0x1cc7,
-	0x1cd0, 0x1cf9,
+	0x1c4d, 0x1c8a,
+	0x1c90, 0x1cba,
+	0x1cbd, 0x1cc7,
+	0x1cd0, 0x1cfa,
 	0x1d00, 0x1f15,
 	0x1f18, 0x1f1d,
 	0x1f20, 0x1f45,
@@ -163,45 +158,35 @@ var isPrint16 = []uint16{
 	0x2030, 0x205e,
 	0x2070, 0x2071,
 	0x2074, 0x209c,
-	0x20a0, 0x20bf,
+	0x20a0, 0x20c1,
 	0x20d0, 0x20f0,
 	0x2100, 0x218b,
-	0x2190, 0x2426,
+	0x2190, 0x2429,
 	0x2440, 0x244a,
 	0x2460, 0x2b73,
-	0x2b76, 0x2b95,
-	0x2b98, 0x2bb9,
-	0x2bbd, 0x2bd2,
-	0x2bec, 0x2bef,
-	0x2c00, 0x2cf3,
+	0x2b76, 0x2cf3,
 	0x2cf9, 0x2d27,
 	0x2d2d, 0x2d2d,
 	0x2d30, 0x2d67,
 	0x2d6f, 0x2d70,
 	0x2d7f, 0x2d96,
-	0x2da0, 0x2e49,
+	0x2da0, 0x2e5d,
 	0x2e80, 0x2ef3,
 	0x2f00, 0x2fd5,
-	0x2ff0, 0x2ffb,
-	0x3001, 0x3096,
+	0x2ff0, 0x3096,
 	0x3099, 0x30ff,
-	0x3105, 0x312e,
-	0x3131, 0x31ba,
-	0x31c0, 0x31e3,
-	0x31f0, 0x4db5,
-	0x4dc0, 0x9fea,
-	0xa000, 0xa48c,
+	0x3105, 0x31e5,
+	0x31ef, 0xa48c,
 	0xa490, 0xa4c6,
 	0xa4d0, 0xa62b,
 	0xa640, 0xa6f7,
-	0xa700, 0xa7b7,
-	0xa7f7, 0xa82b,
+	0xa700, 0xa7dc,
+	0xa7f1, 0xa82c,
 	0xa830, 0xa839,
 	0xa840, 0xa877,
 	0xa880, 0xa8c5,
 	0xa8ce, 0xa8d9,
-	0xa8e0, 0xa8fd,
-	0xa900, 0xa953,
+	0xa8e0, 0xa953,
 	0xa95f, 0xa97c,
 	0xa980, 0xa9d9,
 	0xa9de, 0xaa36,
@@ -212,7 +197,7 @@ var isPrint16 = []uint16{
 	0xab01, 0xab06,
 	0xab09, 0xab0e,
 	0xab11, 0xab16,
-	0xab20, 0xab65,
+	0xab20, 0xab6b,
 	0xab70, 0xabed,
 	0xabf0, 0xabf9,
 	0xac00, 0xd7a3,
@@ -222,12 +207,8 @@ var isPrint16 = []uint16{
 	0xfa70, 0xfad9,
 	0xfb00, 0xfb06,
 	0xfb13, 0xfb17,
-	0xfb1d, 0xfbc1,
-	0xfbd3, 0xfd3f,
-	0xfd50, 0xfd8f,
-	0xfd92, 0xfdc7,
-	0xfdf0, 0xfdfd,
-	0xfe00, 0xfe19,
+	0xfb1d, 0xfdcf,
+	0xfdf0, 0xfe19,
 	0xfe20, 0xfe6b,
 	0xfe70, 0xfefc,
 	0xff01, 0xffbe,
@@ -245,13 +226,11 @@ var isNotPrint16 = []uint16{
 	0x038d,
 	0x03a2,
 	0x0530,
-	0x0560,
-	0x0588,
 	0x0590,
+	0x061c,
 	0x06dd,
 	0x083f,
 	0x085f,
-	0x08b5,
 	0x08e2,
 	0x0984,
 	0x09a9,
@@ -283,14 +262,13 @@ var isNotPrint16 = []uint16{
 	0x0b9b,
 	0x0b9d,
 	0x0bc9,
-	0x0c04,
 	0x0c0d,
 	0x0c11,
 	0x0c29,
 	0x0c45,
 	0x0c49,
 	0x0c57,
-	0x0c84,
+	0x0c5b,
 	0x0c8d,
 	0x0c91,
 	0x0ca9,
@@ -299,26 +277,24 @@ var isNotPrint16 = []uint16{
 	0x0cc9,
 	0x0cdf,
 	0x0cf0,
-	0x0d04,
 	0x0d0d,
 	0x0d11,
 	0x0d45,
 	0x0d49,
+	0x0d80,
 	0x0d84,
 	0x0db2,
 	0x0dbc,
 	0x0dd5,
 	0x0dd7,
 	0x0e83,
-	0x0e89,
-	0x0e98,
-	0x0ea0,
+	0x0e85,
+	0x0e8b,
 	0x0ea4,
 	0x0ea6,
-	0x0eac,
-	0x0eba,
 	0x0ec5,
 	0x0ec7,
+	0x0ecf,
 	0x0f48,
 	0x0f98,
 	0x0fbd,
@@ -334,12 +310,12 @@ var isNotPrint16 = []uint16{
 	0x12d7,
 	0x1311,
 	0x1680,
-	0x170d,
 	0x176d,
 	0x1771,
+	0x180e,
 	0x191f,
 	0x1a5f,
-	0x1dfa,
+	0x1b4d,
 	0x1f58,
 	0x1f5a,
 	0x1f5c,
@@ -349,9 +325,6 @@ var isNotPrint16 = []uint16{
 	0x1fdc,
 	0x1ff5,
 	0x208f,
-	0x2bc9,
-	0x2c2f,
-	0x2c5f,
 	0x2d26,
 	0x2da7,
 	0x2daf,
@@ -362,11 +335,11 @@ var isNotPrint16 = []uint16{
 	0x2dd7,
 	0x2ddf,
 	0x2e9a,
+	0x3000,
 	0x3040,
+	0x3130,
 	0x318f,
 	0x321f,
-	0x32ff,
-	0xa7af,
 	0xa9ce,
 	0xa9ff,
 	0xab27,
@@ -388,7 +361,7 @@ var isPrint32 = []uint32{
 	0x010080, 0x0100fa,
 	0x010100, 0x010102,
 	0x010107, 0x010133,
-	0x010137, 0x01019b,
+	0x010137, 0x01019c,
 	0x0101a0, 0x0101a0,
 	0x0101d0, 0x0101fd,
 	0x010280, 0x01029c,
@@ -405,10 +378,12 @@ var isPrint32 = []uint32{
 	0x0104d8, 0x0104fb,
 	0x010500, 0x010527,
 	0x010530, 0x010563,
-	0x01056f, 0x01056f,
+	0x01056f, 0x0105bc,
+	0x0105c0, 0x0105f3,
 	0x010600, 0x010736,
 	0x010740, 0x010755,
 	0x010760, 0x010767,
+	0x010780, 0x0107ba,
 	0x010800, 0x010805,
 	0x010808, 0x010838,
 	0x01083c, 0x01083c,
@@ -417,13 +392,13 @@ var isPrint32 = []uint32{
 	0x0108e0, 0x0108f5,
 	0x0108fb, 0x01091b,
 	0x01091f, 0x010939,
-	0x01093f, 0x01093f,
+	0x01093f, 0x010959,
 	0x010980, 0x0109b7,
 	0x0109bc, 0x0109cf,
 	0x0109d2, 0x010a06,
-	0x010a0c, 0x010a33,
+	0x010a0c, 0x010a35,
 	0x010a38, 0x010a3a,
-	0x010a3f, 0x010a47,
+	0x010a3f, 0x010a48,
 	0x010a50, 0x010a58,
 	0x010a60, 0x010a9f,
 	0x010ac0, 0x010ae6,
@@ -437,25 +412,35 @@ var isPrint32 = []uint32{
 	0x010c00, 0x010c48,
 	0x010c80, 0x010cb2,
 	0x010cc0, 0x010cf2,
-	0x010cfa, 0x010cff,
-	0x010e60, 0x010e7e,
+	0x010cfa, 0x010d27,
+	0x010d30, 0x010d39,
+	0x010d40, 0x010d65,
+	0x010d69, 0x010d85,
+	0x010d8e, 0x010d8f,
+	0x010e60, 0x010ead,
+	0x010eb0, 0x010eb1,
+	0x010ec2, 0x010ec7,
+	0x010ed0, 0x010ed8,
+	0x010efa, 0x010f27,
+	0x010f30, 0x010f59,
+	0x010f70, 0x010f89,
+	0x010fb0, 0x010fcb,
+	0x010fe0, 0x010ff6,
 	0x011000, 0x01104d,
-	0x011052, 0x01106f,
-	0x01107f, 0x0110c1,
+	0x011052, 0x011075,
+	0x01107f, 0x0110c2,
 	0x0110d0, 0x0110e8,
 	0x0110f0, 0x0110f9,
-	0x011100, 0x011143,
+	0x011100, 0x011147,
 	0x011150, 0x011176,
-	0x011180, 0x0111cd,
-	0x0111d0, 0x0111f4,
-	0x011200, 0x01123e,
+	0x011180, 0x0111f4,
+	0x011200, 0x011241,
 	0x011280, 0x0112a9,
 	0x0112b0, 0x0112ea,
 	0x0112f0, 0x0112f9,
 	0x011300, 0x01130c,
 	0x01130f, 0x011310,
-	0x011313, 0x011339,
-	0x01133c, 0x011344,
+	0x011313, 0x011344,
 	0x011347, 0x011348,
 	0x01134b, 0x01134d,
 	0x011350, 0x011350,
@@ -463,7 +448,11 @@ var isPrint32 = []uint32{
 	0x01135d, 0x011363,
 	0x011366, 0x01136c,
 	0x011370, 0x011374,
-	0x011400, 0x01145d,
+	0x011380, 0x01138b,
+	0x01138e, 0x0113c2,
+	0x0113c5, 0x0113d8,
+	0x0113e1, 0x0113e2,
+	0x011400, 0x011461,
 	0x011480, 0x0114c7,
 	0x0114d0, 0x0114d9,
 	0x011580, 0x0115b5,
@@ -471,17 +460,29 @@ var isPrint32 = []uint32{
 	0x011600, 0x011644,
 	0x011650, 0x011659,
 	0x011660, 0x01166c,
-	0x011680, 0x0116b7,
+	0x011680, 0x0116b9,
 	0x0116c0, 0x0116c9,
-	0x011700, 0x011719,
+	0x0116d0, 0x0116e3,
+	0x011700, 0x01171a,
 	0x01171d, 0x01172b,
-	0x011730, 0x01173f,
+	0x011730, 0x011746,
+	0x011800, 0x01183b,
 	0x0118a0, 0x0118f2,
-	0x0118ff, 0x0118ff,
+	0x0118ff, 0x011906,
+	0x011909, 0x011909,
+	0x01190c, 0x011938,
+	0x01193b, 0x011946,
+	0x011950, 0x011959,
+	0x0119a0, 0x0119a7,
+	0x0119aa, 0x0119d7,
+	0x0119da, 0x0119e4,
 	0x011a00, 0x011a47,
-	0x011a50, 0x011a83,
-	0x011a86, 0x011aa2,
-	0x011ac0, 0x011af8,
+	0x011a50, 0x011aa2,
+	0x011ab0, 0x011af8,
+	0x011b00, 0x011b09,
+	0x011b60, 0x011b67,
+	0x011bc0, 0x011be1,
+	0x011bf0, 0x011bf9,
 	0x011c00, 0x011c45,
 	0x011c50, 0x011c6c,
 	0x011c70, 0x011c8f,
@@ -489,39 +490,71 @@ var isPrint32 = []uint32{
 	0x011d00, 0x011d36,
 	0x011d3a, 0x011d47,
 	0x011d50, 0x011d59,
-	0x012000, 0x012399,
+	0x011d60, 0x011d98,
+	0x011da0, 0x011da9,
+	0x011db0, 0x011ddb,
+	0x011de0, 0x011de9,
+	0x011ee0, 0x011ef8,
+	0x011f00, 0x011f3a,
+	0x011f3e, 0x011f5a,
+	0x011fb0, 0x011fb0,
+	0x011fc0, 0x011ff1,
+	0x011fff, 0x012399,
 	0x012400, 0x012474,
 	0x012480, 0x012543,
-	0x013000, 0x01342e,
+	0x012f90, 0x012ff2,
+	0x013000, 0x01342f,
+	0x013440, 0x013455,
+	0x013460, 0x0143fa,
 	0x014400, 0x014646,
+	0x016100, 0x016139,
 	0x016800, 0x016a38,
 	0x016a40, 0x016a69,
-	0x016a6e, 0x016a6f,
+	0x016a6e, 0x016ac9,
 	0x016ad0, 0x016aed,
 	0x016af0, 0x016af5,
 	0x016b00, 0x016b45,
 	0x016b50, 0x016b77,
 	0x016b7d, 0x016b8f,
-	0x016f00, 0x016f44,
-	0x016f50, 0x016f7e,
+	0x016d40, 0x016d79,
+	0x016e40, 0x016e9a,
+	0x016ea0, 0x016eb8,
+	0x016ebb, 0x016ed3,
+	0x016f00, 0x016f4a,
+	0x016f4f, 0x016f87,
 	0x016f8f, 0x016f9f,
-	0x016fe0, 0x016fe1,
-	0x017000, 0x0187ec,
-	0x018800, 0x018af2,
-	0x01b000, 0x01b11e,
+	0x016fe0, 0x016fe4,
+	0x016ff0, 0x016ff6,
+	0x017000, 0x018cd5,
+	0x018cff, 0x018d1e,
+	0x018d80, 0x018df2,
+	0x01aff0, 0x01b122,
+	0x01b132, 0x01b132,
+	0x01b150, 0x01b152,
+	0x01b155, 0x01b155,
+	0x01b164, 0x01b167,
 	0x01b170, 0x01b2fb,
 	0x01bc00, 0x01bc6a,
 	0x01bc70, 0x01bc7c,
 	0x01bc80, 0x01bc88,
 	0x01bc90, 0x01bc99,
 	0x01bc9c, 0x01bc9f,
+	0x01cc00, 0x01ccfc,
+	0x01cd00, 0x01ceb3,
+	0x01ceba, 0x01ced0,
+	0x01cee0, 0x01cef0,
+	0x01cf00, 0x01cf2d,
+	0x01cf30, 0x01cf46,
+	0x01cf50, 0x01cfc3,
 	0x01d000, 0x01d0f5,
 	0x01d100, 0x01d126,
 	0x01d129, 0x01d172,
-	0x01d17b, 0x01d1e8,
+	0x01d17b, 0x01d1ea,
 	0x01d200, 0x01d245,
+	0x01d2c0, 0x01d2d3,
+	0x01d2e0, 0x01d2f3,
 	0x01d300, 0x01d356,
-	0x01d360, 0x01d371,
+	0x01d360, 0x01d378,
 	0x01d400, 0x01d49f,
 	0x01d4a2, 0x01d4a2,
 	0x01d4a5, 0x01d4a6,
@@ -531,13 +564,31 @@ var isPrint32 = []uint32{
 	0x01d6a8, 0x01d7cb,
 	0x01d7ce, 0x01da8b,
 	0x01da9b, 0x01daaf,
+	0x01df00, 0x01df1e,
+	0x01df25, 0x01df2a,
 	0x01e000, 0x01e018,
 	0x01e01b, 0x01e02a,
-	0x01e800, 0x01e8c4,
+	0x01e030, 0x01e06d,
+	0x01e08f, 0x01e08f,
+	0x01e100, 0x01e12c,
+	0x01e130, 0x01e13d,
+	0x01e140, 0x01e149,
+	0x01e14e, 0x01e14f,
+	0x01e290, 0x01e2ae,
+	0x01e2c0, 0x01e2f9,
+	0x01e2ff, 0x01e2ff,
+	0x01e4d0, 0x01e4f9,
+	0x01e5d0, 0x01e5fa,
+	0x01e5ff, 0x01e5ff,
+	0x01e6c0, 0x01e6f5,
+	0x01e6fe, 0x01e6ff,
+	0x01e7e0, 0x01e8c4,
 	0x01e8c7, 0x01e8d6,
-	0x01e900, 0x01e94a,
+	0x01e900, 0x01e94b,
 	0x01e950, 0x01e959,
 	0x01e95e, 0x01e95f,
+	0x01ec71, 0x01ecb4,
+	0x01ed01, 0x01ed3d,
 	0x01ee00, 0x01ee24,
 	0x01ee27, 0x01ee3b,
 	0x01ee42, 0x01ee42,
@@ -550,36 +601,43 @@ var isPrint32 = []uint32{
 	0x01f030, 0x01f093,
 	0x01f0a0, 0x01f0ae,
 	0x01f0b1, 0x01f0f5,
-	0x01f100, 0x01f10c,
-	0x01f110, 0x01f16b,
-	0x01f170, 0x01f1ac,
+	0x01f100, 0x01f1ad,
 	0x01f1e6, 0x01f202,
 	0x01f210, 0x01f23b,
 	0x01f240, 0x01f248,
 	0x01f250, 0x01f251,
 	0x01f260, 0x01f265,
-	0x01f300, 0x01f6d4,
-	0x01f6e0, 0x01f6ec,
-	0x01f6f0, 0x01f6f8,
-	0x01f700, 0x01f773,
-	0x01f780, 0x01f7d4,
+	0x01f300, 0x01f6d8,
+	0x01f6dc, 0x01f6ec,
+	0x01f6f0, 0x01f6fc,
+	0x01f700, 0x01f7d9,
+	0x01f7e0, 0x01f7eb,
+	0x01f7f0, 0x01f7f0,
 	0x01f800, 0x01f80b,
 	0x01f810, 0x01f847,
 	0x01f850, 0x01f859,
 	0x01f860, 0x01f887,
 	0x01f890, 0x01f8ad,
-	0x01f900, 0x01f90b,
-	0x01f910, 0x01f94c,
-	0x01f950, 0x01f96b,
-	0x01f980, 0x01f997,
-	0x01f9c0, 0x01f9c0,
-	0x01f9d0, 0x01f9e6,
-	0x020000, 0x02a6d6,
-	0x02a700, 0x02b734,
-	0x02b740, 0x02b81d,
-	0x02b820, 0x02cea1,
+	0x01f8b0, 0x01f8bb,
+	0x01f8c0, 0x01f8c1,
+	0x01f8d0, 0x01f8d8,
+	0x01f900, 0x01fa57,
+	0x01fa60, 0x01fa6d,
+	0x01fa70, 0x01fa7c,
+	0x01fa80, 0x01fa8a,
+	0x01fa8e, 0x01fac8,
+	0x01facd, 0x01fadc,
+	0x01fadf, 0x01faea,
+	0x01faef, 0x01faf8,
+	0x01fb00, 0x01fbfa,
+	0x020000, 0x02a6df,
+	0x02a700, 0x02b81d,
+	0x02b820, 0x02cead,
 	0x02ceb0, 0x02ebe0,
+	0x02ebf0, 0x02ee5d,
 	0x02f800, 0x02fa1d,
+	0x030000, 0x03134a,
+	0x031350, 0x033479,
 	0x0e0100, 0x0e01ef,
 }
 
@@ -590,6 +648,15 @@ var isNotPrint32 = []uint16{ // add 0x10000 to each entry
 	0x003e,
 	0x018f,
 	0x039e,
+	0x057b,
+	0x058b,
+	0x0593,
+	0x0596,
+	0x05a2,
+	0x05b2,
+	0x05ba,
+	0x0786,
+	0x07b1,
 	0x0809,
 	0x0836,
 	0x0856,
@@ -597,6 +664,8 @@ var isNotPrint32 = []uint16{ // add 0x10000 to each entry
 	0x0a04,
 	0x0a14,
 	0x0a18,
+	0x0e7f,
+	0x0eaa,
 	0x10bd,
 	0x1135,
 	0x11e0,
@@ -609,9 +678,18 @@ var isNotPrint32 = []uint16{ // add 0x10000 to each entry
 	0x1329,
 	0x1331,
 	0x1334,
-	0x145a,
+	0x133a,
+	0x138a,
+	0x138f,
+	0x13b6,
+	0x13c1,
+	0x13c6,
+	0x13cb,
+	0x13d6,
 	0x145c,
-	0x1a9d,
+	0x1914,
+	0x1917,
+	0x1936,
 	0x1c09,
 	0x1c37,
 	0x1ca8,
@@ -619,10 +697,19 @@ var isNotPrint32 = []uint16{ // add 0x10000 to each entry
 	0x1d0a,
 	0x1d3b,
 	0x1d3e,
+	0x1d66,
+	0x1d69,
+	0x1d8f,
+	0x1d92,
+	0x1f11,
 	0x246f,
 	0x6a5f,
+	0x6abf,
 	0x6b5a,
 	0x6b62,
+	0xaff4,
+	0xaffc,
+	0xafff,
 	0xd455,
 	0xd49d,
 	0xd4ad,
@@ -640,6 +727,11 @@ var isNotPrint32 = []uint16{ // add 0x10000 to each entry
 	0xe007,
 	0xe022,
 	0xe025,
+	0xe6df,
+	0xe7e7,
+	0xe7ec,
+	0xe7ef,
+	0xe7ff,
 	0xee04,
 	0xee20,
 	0xee23,
@@ -668,8 +760,8 @@ var isNotPrint32 = []uint16{ // add 0x10000 to each entry
 	0xeeaa,
 	0xf0c0,
 	0xf0d0,
-	0xf12f,
-	0xf93f,
+	0xfac7,
+	0xfb93,
 }
 
 // isGraphic lists the graphic runes not matched by IsPrint.
@@ -41,6 +41,14 @@ func AppendInt(dst []byte, i int64, base int) []byte {
 	if fastSmalls && 0 <= i && i < nSmalls && base == 10 {
 		return append(dst, small(int(i))...)
 	}
+	if base == 10 {
+		u := uint64(i)
+		if i < 0 {
+			dst = append(dst, '-')
+			u = -u
+		}
+		return appendDecimal(dst, u)
+	}
 	dst, _ = formatBits(dst, uint64(i), base, i < 0, true)
 	return dst
 }
@@ -51,6 +59,9 @@ func AppendUint(dst []byte, i uint64, base int) []byte {
 	if fastSmalls && i < nSmalls && base == 10 {
 		return append(dst, small(int(i))...)
 	}
+	if base == 10 {
+		return appendDecimal(dst, i)
+	}
 	dst, _ = formatBits(dst, i, base, false, true)
 	return dst
 }
@@ -78,6 +89,80 @@ const smallsString = "00010203040506070809" +
 
 const host32bit = ^uint(0)>>32 == 0
 
+// decimalLen returns the number of decimal digits needed to represent u.
+func decimalLen(u uint64) int {
+	// 1233/4096 approximates log10(2); the estimate is either exact
+	// or one too large, which the table lookup corrects.
+	n := bits.Len64(u|1) * 1233 >> 12
+	if u|1 < uint64pow10[n] {
+		return n
+	}
+	return n + 1
+}
+
+// appendDecimal appends the decimal representation of u to dst and
+// returns the extended buffer. The digits are written in place at the
+// end of dst, without going through an intermediate array.
+//
+// At most two 64-bit divisions are needed to split u into 8-digit
+// blocks; the blocks are then converted with 32-bit operations,
+// two digits at a time, using smallsString.
+func appendDecimal(dst []byte, u uint64) []byte {
+	n := decimalLen(u)
+	if cap(dst)-len(dst) >= n {
+		dst = dst[:len(dst)+n]
+	} else {
+		dst = append(dst, make([]byte, n)...)
+	}
+	i := len(dst)
+
+	for u >= 1e8 {
+		q := u / 1e8
+		i = put8Digits(dst, i, uint32(u-q*1e8))
+		u = q
+	}
+
+	// u < 1e8 fits into a uint32
+	us := uint32(u)
+	for us >= 100 {
+		is := us % 100 * 2
+		us /= 100
+		i -= 2
+		dst[i+1] = smallsString[is+1]
+		dst[i+0] = smallsString[is+0]
+	}
+
+	// us < 100
+	is := us * 2
+	i--
+	dst[i] = smallsString[is+1]
+	if us >= 10 {
+		i--
+		dst[i] = smallsString[is]
+	}
+	return dst
+}
+
+// put8Digits writes the 8 decimal digits of v < 1e8, zero padded,
+// to dst[i-8:i] and returns i-8.
+func put8Digits(dst []byte, i int, v uint32) int {
+	hi := v / 10000
+	lo := v - hi*10000
+	h1, h2 := hi/100*2, hi%100*2
+	l1, l2 := lo/100*2, lo%100*2
+	i -= 8
+	dst = dst[i : i+8]
+	dst[7] = smallsString[l2+1]
+	dst[6] = smallsString[l2]
+	dst[5] = smallsString[l1+1]
+	dst[4] = smallsString[l1]
+	dst[3] = smallsString[h2+1]
+	dst[2] = smallsString[h2]
+	dst[1] = smallsString[h1+1]
+	dst[0] = smallsString[h1]
+	return i
+}
+
 const digits = "0123456789abcdefghijklmnopqrstuvwxyz"
 
 // formatBits computes the string representation of u in the given base.
@@ -91,6 +176,15 @@ func formatBits(dst []byte, u uint64, base int, neg, append_ bool) (d []byte, s
 		panic("bconv: illegal AppendInt/FormatInt base")
 	}
 	// 2 <= base && base <= len(digits)
+	return formatBitsIn(dst, u, digits[:base], base == 10, neg, append_)
+}
+
+// formatBitsIn is like formatBits but uses the digits of alphabet,
+// in base len(alphabet). It requires 2 <= len(alphabet) <= 255.
+// If decimal is set, alphabet must be digits[:10]; the conversion
+// then uses the faster base 10 code.
+func formatBitsIn(dst []byte, u uint64, alphabet string, decimal, neg, append_ bool) (d []byte, s string) {
+	base := len(alphabet)
 
 	var a [64 + 1]byte // +1 for sign of 64bit value in base 2
 	i := len(a)
@@ -102,7 +196,7 @@ func formatBits(dst []byte, u uint64, base int, neg, append_ bool) (d []byte, s
 	// convert bits
 	// We use uint values where we can because those will
 	// fit into a single register even on a 32bit machine.
-	if base == 10 {
+	if decimal {
 		// common case: use constants for / because
 		// the compiler can optimize it into a multiply+shift
 
@@ -152,24 +246,7 @@ func formatBits(dst []byte, u uint64, base int, neg, append_ bool) (d []byte, s
 		}
 
 	} else if isPowerOfTwo(base) {
-		// Use shifts and masks instead of / and %.
-		// Base is a power of 2 and 2 <= base <= len(digits) where len(digits) is 36.
-		// The largest power of 2 below or equal to 36 is 32, which is 1 << 5;
-		// i.e., the largest possible shift count is 5. By &-ind that value with
-		// the constant 7 we tell the compiler that the shift count is always
-		// less than 8 which is smaller than any register width. This allows
-		// the compiler to generate better code for the shift operation.
-		shift := uint(bits.TrailingZeros(uint(base))) & 7
-		b := uint64(base)
-		m := uint(base) - 1 // == 1<<shift - 1
-		for u >= b {
-			i--
-			a[i] = digits[uint(u)&m]
-			u >>= shift
-		}
-		// u < base
-		i--
-		a[i] = digits[uint(u)]
+		i = formatPow2(a[:i], u, alphabet, 1)
 	} else {
 		// general case
 		b := uint64(base)
@@ -179,12 +256,12 @@ func formatBits(dst []byte, u uint64, base int, neg, append_ bool) (d []byte, s
 			// since 64bit division and modulo operations
 			// are calculated by runtime functions on 32bit machines.
 			q := u / b
-			a[i] = digits[uint(u-q*b)]
+			a[i] = alphabet[uint(u-q*b)]
 			u = q
 		}
 		// u < base
 		i--
-		a[i] = digits[uint(u)]
+		a[i] = alphabet[uint(u)]
 	}
 
 	// add sign, if any
@@ -201,6 +278,33 @@ func formatBits(dst []byte, u uint64, base int, neg, append_ bool) (d []byte, s
 	return
 }
 
+// formatPow2 writes the digits of u in base len(alphabet), which must be
+// a power of two, to the end of a, zero padded to at least width digits.
+// It returns the index in a of the first digit.
+func formatPow2(a []byte, u uint64, alphabet string, width int) int {
+	// Use shifts and masks instead of / and %.
+	// Base is a power of 2 and 2 <= base <= len(alphabet) where len(alphabet) is at most 255.
+	// The largest power of 2 below or equal to 255 is 128, which is 1 << 7;
+	// i.e., the largest possible shift count is 7. By &-ind that value with
+	// the constant 7 we tell the compiler that the shift count is always
+	// less than 8 which is smaller than any register width. This allows
+	// the compiler to generate better code for the shift operation.
+	base := len(alphabet)
+	shift := uint(bits.TrailingZeros(uint(base))) & 7
+	b := uint64(base)
+	m := uint(base) - 1 // == 1<<shift - 1
+	i := len(a)
+	for u >= b || len(a)-i < width-1 {
+		i--
+		a[i] = alphabet[uint(u)&m]
+		u >>= shift
+	}
+	// u < base
+	i--
+	a[i] = alphabet[uint(u)]
+	return i
+}
+
 func isPowerOfTwo(x int) bool {
 	return x&(x-1) == 0
 }
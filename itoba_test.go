@@ -160,6 +160,41 @@ func TestFormatUintVarlen(t *testing.T) {
 	}
 }
 
+func TestAppendUintVarlen(t *testing.T) {
+	for _, test := range varlenUints {
+		for _, dst := range [][]byte{nil, []byte("x="), make([]byte, 2, 40)} {
+			prefix := string(dst)
+			out := string(AppendUint(dst, test.in, 10))
+			if want := prefix + test.out; out != want {
+				t.Errorf("AppendUint(%q, %v, 10) = %q want %q", prefix, test.in, out, want)
+			}
+			out = string(AppendInt(dst, -int64(test.in>>1), 10))
+			if want := prefix + FormatInt(-int64(test.in>>1), 10); out != want {
+				t.Errorf("AppendInt(%q, %v, 10) = %q want %q", prefix, -int64(test.in>>1), out, want)
+			}
+		}
+	}
+}
+
+func TestDecimalLen(t *testing.T) {
+	for i, p := range uint64pow10 {
+		if i > 0 {
+			if n := decimalLen(p - 1); n != i {
+				t.Errorf("decimalLen(%d) = %d want %d", p-1, n, i)
+			}
+		}
+		if n := decimalLen(p); n != i+1 {
+			t.Errorf("decimalLen(%d) = %d want %d", p, n, i+1)
+		}
+	}
+	if n := decimalLen(0); n != 1 {
+		t.Errorf("decimalLen(0) = %d want 1", n)
+	}
+	if n := decimalLen(1<<64 - 1); n != 20 {
+		t.Errorf("decimalLen(%d) = %d want 20", uint64(1<<64-1), n)
+	}
+}
+
 func BenchmarkFormatInt(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		for _, test := range itob64tests {
@@ -219,6 +254,19 @@ func BenchmarkAppendIntSmall(b *testing.B) {
 	}
 }
 
+func BenchmarkAppendIntVarlen(b *testing.B) {
+	for _, test := range varlenUints {
+		i := -int64(test.in >> 1)
+		b.Run(FormatInt(i, 10), func(b *testing.B) {
+			dst := make([]byte, 0, 30)
+			for j := 0; j < b.N; j++ {
+				dst = AppendInt(dst[:0], i, 10)
+				BenchSink += len(dst)
+			}
+		})
+	}
+}
+
 func BenchmarkAppendUintVarlen(b *testing.B) {
 	for _, test := range varlenUints {
 		b.Run(test.out, func(b *testing.B) {
@@ -0,0 +1,163 @@
+package baconv
+
+// A NumberKind is the narrowest Go type that represents a JSON number
+// exactly, as reported by ClassifyJSONNumber.
+type NumberKind int
+
+const (
+	// NumberInt is an integer, written without a fraction or exponent,
+	// that fits in an int64.
+	NumberInt NumberKind = iota
+
+	// NumberUint is a non-negative integer, written without a fraction
+	// or exponent, that fits in a uint64 but not in an int64.
+	NumberUint
+
+	// NumberFloat is any other number, to be parsed as a float64.
+	NumberFloat
+)
+
+// scanJSONNumber checks that ba is a number in the JSON grammar of
+// RFC 8259,
+//
+//	-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?
+//
+// and classifies it. For integers it also returns their magnitude, which
+// is valid if kind is not NumberFloat.
+func scanJSONNumber(ba []byte) (kind NumberKind, mag uint64, neg, ok bool) {
+	const maxUint64 = 1<<64 - 1
+	i := 0
+	if i < len(ba) && ba[i] == '-' {
+		neg = true
+		i++
+	}
+	if i >= len(ba) {
+		return
+	}
+
+	// integer part: no leading zeros
+	overflow := false
+	switch c := ba[i]; {
+	case c == '0':
+		i++
+	case '1' <= c && c <= '9':
+		for ; i < len(ba) && '0' <= ba[i] && ba[i] <= '9'; i++ {
+			d := uint64(ba[i] - '0')
+			if mag > (maxUint64-d)/10 {
+				overflow = true
+			}
+			mag = mag*10 + d
+		}
+	default:
+		return
+	}
+	kind = NumberInt
+
+	// fraction
+	if i < len(ba) && ba[i] == '.' {
+		kind = NumberFloat
+		i++
+		if i >= len(ba) || ba[i] < '0' || ba[i] > '9' {
+			return
+		}
+		for i < len(ba) && '0' <= ba[i] && ba[i] <= '9' {
+			i++
+		}
+	}
+
+	// exponent
+	if i < len(ba) && (ba[i] == 'e' || ba[i] == 'E') {
+		kind = NumberFloat
+		i++
+		if i < len(ba) && (ba[i] == '+' || ba[i] == '-') {
+			i++
+		}
+		if i >= len(ba) || ba[i] < '0' || ba[i] > '9' {
+			return
+		}
+		for i < len(ba) && '0' <= ba[i] && ba[i] <= '9' {
+			i++
+		}
+	}
+
+	if i != len(ba) {
+		return
+	}
+	switch {
+	case kind == NumberFloat:
+	case overflow:
+		kind = NumberFloat
+	case neg:
+		if mag > 1<<63 {
+			kind = NumberFloat
+		}
+	case mag > 1<<63-1:
+		kind = NumberUint
+	}
+	ok = true
+	return
+}
+
+// ClassifyJSONNumber checks that ba is a number as defined by JSON
+// (RFC 8259) and reports the narrowest kind that represents it exactly.
+// Integers are converted in the same pass: if kind is NumberInt, i holds
+// the value, and if kind is NumberUint, u does. For NumberFloat, use
+// ParseJSONNumber. Only numbers written without a fraction or exponent
+// are integers: 1.0 and 1e2 are NumberFloat. Negative zero is NumberInt.
+//
+// The JSON grammar has no leading plus sign, no leading zeros, no
+// special values such as Inf and NaN, and requires digits on both sides
+// of a decimal point; numbers using these are rejected.
+//
+// The error that ClassifyJSONNumber returns has concrete type *NumError
+// and includes err.Num = s and err.Err = ErrSyntax.
+func ClassifyJSONNumber(ba []byte) (kind NumberKind, i int64, u uint64, err error) {
+	const fnClassifyJSONNumber = "ClassifyJSONNumber"
+
+	kind, mag, neg, ok := scanJSONNumber(ba)
+	if !ok {
+		return NumberFloat, 0, 0, syntaxError(fnClassifyJSONNumber, string(ba))
+	}
+	switch {
+	case kind == NumberUint:
+		u = mag
+	case kind == NumberInt && neg:
+		i = -int64(mag) // also right for -1<<63
+	case kind == NumberInt:
+		i = int64(mag)
+	}
+	return kind, i, u, nil
+}
+
+// jsonFloat are the options of ParseFloat that match the JSON grammar.
+var jsonFloat = ParseFloatOptions{}
+
+// ParseJSONNumber converts ba, which must be a number as defined by
+// JSON (RFC 8259), to the nearest float64, as ParseFloat does. The
+// number syntax is checked as by ClassifyJSONNumber.
+//
+// The errors that ParseJSONNumber returns have concrete type *NumError
+// and include err.Num = s. If s is not a JSON number, err.Err = ErrSyntax;
+// if it is out of the range of a float64, ParseJSONNumber returns
+// f = ±Inf, err.Err = ErrRange.
+func ParseJSONNumber(ba []byte) (float64, error) {
+	const fnParseJSONNumber = "ParseJSONNumber"
+
+	kind, mag, neg, ok := scanJSONNumber(ba)
+	if !ok {
+		return 0, syntaxError(fnParseJSONNumber, string(ba))
+	}
+	if kind != NumberFloat && mag <= 1<<53 {
+		// Exactly representable.
+		f := float64(mag)
+		if neg {
+			f = -f
+		}
+		return f, nil
+	}
+	f, err := batof64(ba, &jsonFloat)
+	if err != nil {
+		err.(*NumError).Func = fnParseJSONNumber
+	}
+	return f, err
+}
@@ -0,0 +1,173 @@
+package baconv
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"testing"
+)
+
+var jsonNumberTests = []struct {
+	in   string
+	kind NumberKind
+	out  float64
+	err  error
+}{
+	{"0", NumberInt, 0, nil},
+	{"-0", NumberInt, math.Copysign(0, -1), nil},
+	{"7", NumberInt, 7, nil},
+	{"-42", NumberInt, -42, nil},
+	{"9007199254740993", NumberInt, 9007199254740992, nil},
+	{"9223372036854775807", NumberInt, 9223372036854775807, nil},
+	{"-9223372036854775808", NumberInt, -9223372036854775808, nil},
+	{"9223372036854775808", NumberUint, 9223372036854775808, nil},
+	{"18446744073709551615", NumberUint, 18446744073709551615, nil},
+	{"-9223372036854775809", NumberFloat, -9223372036854775809, nil},
+	{"18446744073709551616", NumberFloat, 18446744073709551616, nil},
+	{"100000000000000000000000", NumberFloat, 1e23, nil},
+	{"0.5", NumberFloat, 0.5, nil},
+	{"-0.0", NumberFloat, math.Copysign(0, -1), nil},
+	{"1.0", NumberFloat, 1, nil},
+	{"1e2", NumberFloat, 100, nil},
+	{"1E+2", NumberFloat, 100, nil},
+	{"25e-1", NumberFloat, 2.5, nil},
+	{"0e0", NumberFloat, 0, nil},
+	{"-1.5e-3", NumberFloat, -0.0015, nil},
+	{"1e400", NumberFloat, math.Inf(1), ErrRange},
+	{"-1e400", NumberFloat, math.Inf(-1), ErrRange},
+	{"1e-400", NumberFloat, 0, nil},
+}
+
+var badJSONNumbers = []string{
+	"",
+	"-",
+	"+1",
+	"01",
+	"-01",
+	"00",
+	".5",
+	"-.5",
+	"5.",
+	"5.e1",
+	"1e",
+	"1e+",
+	"1e-",
+	"1.5.",
+	"0x10",
+	"1_000",
+	" 1",
+	"1 ",
+	"Inf",
+	"-Inf",
+	"infinity",
+	"NaN",
+	"1f",
+	"--1",
+}
+
+func TestParseJSONNumber(t *testing.T) {
+	for _, tt := range jsonNumberTests {
+		out, err := ParseJSONNumber([]byte(tt.in))
+		if err != nil {
+			if e := err.(*NumError); e.Func != "ParseJSONNumber" || e.Num != tt.in {
+				t.Errorf("ParseJSONNumber(%q) error = %#v", tt.in, e)
+			}
+			err = err.(*NumError).Err
+		}
+		if math.Float64bits(out) != math.Float64bits(tt.out) || err != tt.err {
+			t.Errorf("ParseJSONNumber(%q) = %v, %v, want %v, %v", tt.in, out, err, tt.out, tt.err)
+		}
+	}
+	for _, s := range badJSONNumbers {
+		out, err := ParseJSONNumber([]byte(s))
+		if out != 0 || err == nil || err.(*NumError).Err != ErrSyntax {
+			t.Errorf("ParseJSONNumber(%q) = %v, %v, want 0, %v", s, out, err, ErrSyntax)
+		}
+	}
+}
+
+func TestClassifyJSONNumber(t *testing.T) {
+	for _, tt := range jsonNumberTests {
+		kind, i, u, err := ClassifyJSONNumber([]byte(tt.in))
+		if kind != tt.kind || err != nil {
+			t.Errorf("ClassifyJSONNumber(%q) = %d, %d, %d, %v, want kind %d, nil", tt.in, kind, i, u, err, tt.kind)
+			continue
+		}
+		var wi int64
+		var wu uint64
+		switch kind {
+		case NumberInt:
+			wi, err = ParseInt([]byte(tt.in), 10, 64)
+		case NumberUint:
+			wu, err = ParseUint([]byte(tt.in), 10, 64)
+		}
+		if i != wi || u != wu || err != nil {
+			t.Errorf("ClassifyJSONNumber(%q) = %d, %d, %d, want %d, %d (%v)", tt.in, kind, i, u, wi, wu, err)
+		}
+	}
+	for _, s := range badJSONNumbers {
+		_, _, _, err := ClassifyJSONNumber([]byte(s))
+		if err == nil || err.(*NumError).Err != ErrSyntax {
+			t.Errorf("ClassifyJSONNumber(%q) = _, _, _, %v, want %v", s, err, ErrSyntax)
+		}
+	}
+}
+
+func FuzzParseJSONNumber(f *testing.F) {
+	for _, tt := range jsonNumberTests {
+		f.Add([]byte(tt.in))
+	}
+	for _, s := range badJSONNumbers {
+		f.Add([]byte(s))
+	}
+	f.Fuzz(func(t *testing.T, ba []byte) {
+		kind, i, u, err := ClassifyJSONNumber(ba)
+		valid := err == nil
+		var n json.Number
+		jerr := json.Unmarshal(ba, &n)
+		// json.Unmarshal also accepts surrounding white space, and strings
+		// into a json.Number.
+		trimmed := len(ba) > 0 && ba[0] != '"' && ba[0] > ' ' && ba[len(ba)-1] > ' '
+		if valid && jerr != nil || !valid && jerr == nil && trimmed {
+			t.Fatalf("ClassifyJSONNumber(%q) = %v, but json.Unmarshal = %v", ba, err, jerr)
+		}
+		if !valid {
+			return
+		}
+
+		wi, ierr := strconv.ParseInt(string(ba), 10, 64)
+		wu, uerr := strconv.ParseUint(string(ba), 10, 64)
+		switch kind {
+		case NumberInt:
+			if ierr != nil || i != wi || u != 0 {
+				t.Fatalf("ClassifyJSONNumber(%q) = NumberInt, %d, %d, but ParseInt = %d, %v", ba, i, u, wi, ierr)
+			}
+		case NumberUint:
+			if ierr == nil || uerr != nil || u != wu || i != 0 {
+				t.Fatalf("ClassifyJSONNumber(%q) = NumberUint, %d, %d, but ParseInt = %v, ParseUint = %d, %v", ba, i, u, ierr, wu, uerr)
+			}
+		case NumberFloat:
+			if ierr == nil || uerr == nil {
+				t.Fatalf("ClassifyJSONNumber(%q) = NumberFloat, but ParseInt = %v, ParseUint = %v", ba, ierr, uerr)
+			}
+		}
+
+		got, err := ParseJSONNumber(ba)
+		want, werr := strconv.ParseFloat(string(ba), 64)
+		if math.Float64bits(got) != math.Float64bits(want) || (err == nil) != (werr == nil) {
+			t.Fatalf("ParseJSONNumber(%q) = %v, %v, want %v, %v", ba, got, err, want, werr)
+		}
+	})
+}
+
+func BenchmarkParseJSONNumber(b *testing.B) {
+	for _, s := range []string{"12345", "-123.456e-7"} {
+		ba := []byte(s)
+		b.Run(s, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				f, _ := ParseJSONNumber(ba)
+				BenchSink += int(f)
+			}
+		})
+	}
+}
@@ -2,27 +2,47 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+//go:build ignore
 // +build ignore
 
 //
 // usage:
 //
-// go run makeisprint.go -output isprint.go
+// go run makeisprint.go -output isprint.go [-width widthtables.go -eaw testdata/EastAsianWidth.txt]
+//
+// or, equivalently, go generate, which picks up the directive in quote.go.
+// Rerun it whenever the Go toolchain moves to a new Unicode version;
+// TestUnicodeVersion fails until the tables are regenerated.
+//
+// The display width tables need the East Asian Width property, which the
+// unicode package does not provide. It is read from the file named by -eaw,
+// which must be for the toolchain's Unicode version; update the copy in
+// testdata from https://www.unicode.org/Public/<version>/ucd/ along with
+// the toolchain.
 //
 
 package main
 
 import (
+	"bufio"
 	"bytes"
 	"flag"
 	"fmt"
 	"go/format"
 	"io/ioutil"
 	"log"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
 	"unicode"
 )
 
-var filename = flag.String("output", "isprint.go", "output file name")
+var (
+	filename      = flag.String("output", "isprint.go", "output file name")
+	widthFilename = flag.String("width", "", "display width tables output file name; empty to skip")
+	eawFilename   = flag.String("eaw", "", "EastAsianWidth.txt to read; required with -width")
+)
 
 var (
 	range16  []uint16
@@ -109,6 +129,32 @@ func scan(min, max rune) (rang, except []uint32) {
 	return
 }
 
+// ranges returns the inclusive ranges [lo, hi] of runes in [min, max]
+// for which f is true, as a flat list of pairs.
+func ranges(min, max rune, f func(rune) bool) (rang []uint32) {
+	lo := rune(-1)
+	for i := min; i <= max+1; i++ {
+		if i <= max && f(i) {
+			if lo < 0 {
+				lo = i
+			}
+			continue
+		}
+		if lo >= 0 {
+			rang = append(rang, uint32(lo), uint32(i-1))
+			lo = -1
+		}
+	}
+	return
+}
+
+// inRanges is the lookup used by width.go.
+func inRanges(rang []uint32, r rune) bool {
+	rr := uint32(r)
+	i := bsearch32(rang, rr)
+	return i < len(rang) && rang[i&^1] <= rr && rr <= rang[i|1]
+}
+
 func to16(x []uint32) []uint16 {
 	var y []uint16
 	for _, v := range x {
@@ -142,6 +188,9 @@ func main() {
 	fmt.Fprintf(&buf, "// Code generated by go run makeisprint.go -output isprint.go; DO NOT EDIT.\n\n")
 	fmt.Fprintf(&buf, "package baconv\n\n")
 
+	fmt.Fprintf(&buf, "// unicodeVersion is the Unicode version the tables were generated from.\n")
+	fmt.Fprintf(&buf, "const unicodeVersion = %q\n\n", unicode.Version)
+
 	fmt.Fprintf(&buf, "// (%d+%d+%d)*2 + (%d)*4 = %d bytes\n\n",
 		len(range16), len(except16), len(except32),
 		len(range32),
@@ -200,4 +249,140 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
+
+	if *widthFilename != "" {
+		writeWidthTables(*widthFilename)
+	}
+}
+
+var eawVersion = regexp.MustCompile(`^# EastAsianWidth-(.+)\.txt`)
+
+// readEastAsianWidth parses EastAsianWidth.txt and returns the set of runes
+// whose East Asian Width is Wide or Fullwidth, along with the Unicode version
+// named in the file header. The @missing lines, which give the defaults for
+// unlisted code points, are applied before the explicit entries.
+func readEastAsianWidth() (wide []bool, version string) {
+	if *eawFilename == "" {
+		log.Fatal("-width requires -eaw")
+	}
+	f, err := os.Open(*eawFilename)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	wide = make([]bool, unicode.MaxRune+1)
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := s.Text()
+		if m := eawVersion.FindStringSubmatch(line); m != nil {
+			version = m[1]
+			continue
+		}
+		line = strings.TrimPrefix(line, "# @missing:")
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Split(line, ";")
+		if len(fields) != 2 {
+			continue
+		}
+		lo, hi := parseCodePoints(strings.TrimSpace(fields[0]))
+		w := strings.TrimSpace(fields[1])
+		for c := lo; c <= hi; c++ {
+			wide[c] = w == "W" || w == "F"
+		}
+	}
+	if err := s.Err(); err != nil {
+		log.Fatal(err)
+	}
+	if version == "" {
+		log.Fatal("EastAsianWidth.txt: missing version header")
+	}
+	return wide, version
+}
+
+// parseCodePoints parses a code point or a range of the form XXXX..YYYY.
+func parseCodePoints(s string) (lo, hi rune) {
+	l, h := s, s
+	if i := strings.Index(s, ".."); i >= 0 {
+		l, h = s[:i], s[i+2:]
+	}
+	x, err := strconv.ParseUint(l, 16, 32)
+	if err != nil {
+		log.Fatalf("EastAsianWidth.txt: bad code point %q", s)
+	}
+	y, err := strconv.ParseUint(h, 16, 32)
+	if err != nil || y < x || y > unicode.MaxRune {
+		log.Fatalf("EastAsianWidth.txt: bad code point %q", s)
+	}
+	return rune(x), rune(y)
+}
+
+// isZeroWidth reports whether r is a format character or a conjoining
+// Hangul vowel or trailing consonant, all of which occupy no column of
+// their own. The soft hyphen is the exception: terminals display it.
+func isZeroWidth(r rune) bool {
+	return r != 0x00AD && unicode.Is(unicode.Cf, r) ||
+		0x1160 <= r && r <= 0x11FF || 0xD7B0 <= r && r <= 0xD7FF
+}
+
+// isCombining reports whether r is a nonspacing or enclosing mark.
+func isCombining(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me)
+}
+
+func writeWidthTables(name string) {
+	wide, version := readEastAsianWidth()
+	if version != unicode.Version {
+		log.Fatalf("%s is for Unicode %s, toolchain has %s", *eawFilename, version, unicode.Version)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by go run makeisprint.go -output %s -width %s -eaw %s; DO NOT EDIT.\n\n", *filename, name, *eawFilename)
+	fmt.Fprintf(&buf, "package baconv\n\n")
+	fmt.Fprintf(&buf, "// eastAsianWidthVersion is the version of EastAsianWidth.txt the isWide\n")
+	fmt.Fprintf(&buf, "// tables were generated from.\n")
+	fmt.Fprintf(&buf, "const eastAsianWidthVersion = %q\n\n", version)
+
+	tables := []struct {
+		name, doc string
+		f         func(rune) bool
+	}{
+		{"isWide", "East Asian Wide and Fullwidth runes", func(r rune) bool { return wide[r] }},
+		{"isZeroWidth", "format characters and conjoining Hangul jamo", isZeroWidth},
+		{"isCombining", "nonspacing and enclosing marks", isCombining},
+	}
+	for _, t := range tables {
+		rang16 := to16(ranges(0, 0xFFFF, t.f))
+		rang32 := ranges(0x10000, unicode.MaxRune, t.f)
+
+		all := ranges(0, unicode.MaxRune, t.f)
+		for r := rune(0); r <= unicode.MaxRune; r++ {
+			if inRanges(all, r) != t.f(r) {
+				log.Fatalf("%U: %s=%v, want %v\n", r, t.name, !t.f(r), t.f(r))
+			}
+		}
+
+		fmt.Fprintf(&buf, "// %s16 and %s32 list the ranges of %s.\n", t.name, t.name, t.doc)
+		fmt.Fprintf(&buf, "var %s16 = []uint16{\n", t.name)
+		for i := 0; i < len(rang16); i += 2 {
+			fmt.Fprintf(&buf, "\t%#04x, %#04x,\n", rang16[i], rang16[i+1])
+		}
+		fmt.Fprintf(&buf, "}\n\n")
+		fmt.Fprintf(&buf, "var %s32 = []uint32{\n", t.name)
+		for i := 0; i < len(rang32); i += 2 {
+			fmt.Fprintf(&buf, "\t%#06x, %#06x,\n", rang32[i], rang32[i+1])
+		}
+		fmt.Fprintf(&buf, "}\n\n")
+	}
+
+	data, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = ioutil.WriteFile(name, data, 0644)
+	if err != nil {
+		log.Fatal(err)
+	}
 }
@@ -2,67 +2,289 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-//go:generate go run makeisprint.go -output isprint.go
+//go:generate go run makeisprint.go -output isprint.go -width widthtables.go -eaw testdata/EastAsianWidth.txt
 
 package baconv
 
 import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"unicode/utf16"
 	"unicode/utf8"
 )
 
+// ErrUnpairedSurrogate indicates that a \u escape decoded by
+// UnquoteCharUTF16 denotes a UTF-16 surrogate that is not part of a
+// high-low pair.
+var ErrUnpairedSurrogate = errors.New("unpaired UTF-16 surrogate")
+
+// ErrInvalidUTF8 indicates that a value is not valid UTF-8 where text is
+// required.
+var ErrInvalidUTF8 = errors.New("invalid UTF-8")
+
 const lowerhex = "0123456789abcdef"
 
-func quoteWith(s string, quote byte, ASCIIonly, graphicOnly bool) string {
-	return string(appendQuotedWith(make([]byte, 0, 3*len(s)/2), s, quote, ASCIIonly, graphicOnly))
+// A QuoteStyle selects the language whose escape sequences are used to
+// quote and unquote literals.
+type QuoteStyle int
+
+const (
+	// StyleGo is the syntax of Go string and rune literals.
+	StyleGo QuoteStyle = iota
+
+	// StyleC is the syntax of C11 string and character literals. The
+	// GNU extension \e is not supported, and since \u and \U escapes
+	// are used, the literals are not valid C89.
+	StyleC
+
+	// StyleBrace is StyleGo with the Unicode escapes of Rust and
+	// ECMAScript 2015, which enclose one to six hexadecimal digits in
+	// braces, as in \u{1F600}, and replace the fixed-length \u and \U.
+	StyleBrace
+)
+
+// An InvalidUTF8Policy selects how quoting handles bytes that are not
+// part of valid UTF-8. Each such byte is handled separately, so a
+// truncated multi-byte sequence counts as several invalid bytes.
+type InvalidUTF8Policy int
+
+const (
+	// InvalidUTF8Escape writes each invalid byte as a \x escape.
+	InvalidUTF8Escape InvalidUTF8Policy = iota
+
+	// InvalidUTF8Replace writes U+FFFD for each invalid byte, escaped
+	// if the configuration calls for it.
+	InvalidUTF8Replace
+
+	// InvalidUTF8Drop omits invalid bytes.
+	InvalidUTF8Drop
+
+	// InvalidUTF8Error makes AppendQuoteUTF8 fail with ErrInvalidUTF8.
+	// The methods that cannot report an error escape invalid bytes.
+	InvalidUTF8Error
+)
+
+// An EscapeSet is a set of ASCII characters, stored as a bitmap.
+type EscapeSet [2]uint64
+
+// NewEscapeSet returns the set of the characters in chars.
+// It panics if chars contains a byte that is not ASCII.
+func NewEscapeSet(chars string) EscapeSet {
+	var set EscapeSet
+	for i := 0; i < len(chars); i++ {
+		c := chars[i]
+		if c >= utf8.RuneSelf {
+			panic("bconv: non-ASCII byte in escape set")
+		}
+		set[c>>6] |= 1 << (c & 63)
+	}
+	return set
+}
+
+// Contains reports whether r is in the set.
+func (set *EscapeSet) Contains(r rune) bool {
+	return 0 <= r && r < utf8.RuneSelf && set[r>>6]&(1<<(uint(r)&63)) != 0
 }
 
-func quoteRuneWith(r rune, quote byte, ASCIIonly, graphicOnly bool) string {
-	return string(appendQuotedRuneWith(nil, r, quote, ASCIIonly, graphicOnly))
+// A QuoteConfig describes how to quote strings and runes. The Quote*
+// functions are presets of it: Quote, for example, uses a QuoteConfig
+// with Delim '"' and Style StyleGo.
+type QuoteConfig struct {
+	// Delim is the quotation mark that encloses literals and is
+	// backslash-escaped inside them. It must be ASCII punctuation other
+	// than the backslash, so that an escaped delimiter cannot be read as
+	// another escape such as \n or \x. If it is zero, '"' is used.
+	Delim byte
+
+	// Style selects the escape sequences used for characters that are
+	// escaped.
+	Style QuoteStyle
+
+	// ASCIIOnly escapes all non-ASCII characters.
+	ASCIIOnly bool
+
+	// GraphicOnly leaves characters for which IsGraphic is true
+	// unescaped, rather than only those for which IsPrint is.
+	GraphicOnly bool
+
+	// Escape holds additional ASCII characters to escape, such as | for
+	// a pipe-delimited format. They are written as hexadecimal escapes.
+	Escape EscapeSet
+
+	// InvalidUTF8 selects how bytes of strings that are not valid UTF-8
+	// are written.
+	InvalidUTF8 InvalidUTF8Policy
 }
 
-func appendQuotedWith(buf []byte, s string, quote byte, ASCIIonly, graphicOnly bool) []byte {
+// Presets used by the Quote* functions.
+var (
+	goQuote        = QuoteConfig{Delim: '"'}
+	goQuoteASCII   = QuoteConfig{Delim: '"', ASCIIOnly: true}
+	goQuoteGraphic = QuoteConfig{Delim: '"', GraphicOnly: true}
+	goRune         = QuoteConfig{Delim: '\''}
+	goRuneASCII    = QuoteConfig{Delim: '\'', ASCIIOnly: true}
+	goRuneGraphic  = QuoteConfig{Delim: '\'', GraphicOnly: true}
+	cQuote         = QuoteConfig{Delim: '"', Style: StyleC}
+	cRune          = QuoteConfig{Delim: '\'', Style: StyleC}
+)
+
+// delim returns the quotation mark of c, checking that it is valid.
+func (c *QuoteConfig) delim() byte {
+	switch d := c.Delim; {
+	case d == 0:
+		return '"'
+	case !isASCIIPunct(d) || d == '\\':
+		panic("bconv: invalid QuoteConfig delimiter " + QuoteRune(rune(d)))
+	default:
+		return d
+	}
+}
+
+// isASCIIPunct reports whether c is an ASCII punctuation character:
+// printable, and neither a letter, a digit nor a space.
+func isASCIIPunct(c byte) bool {
+	return '!' <= c && c <= '/' || ':' <= c && c <= '@' || '[' <= c && c <= '`' || '{' <= c && c <= '~'
+}
+
+// Quote returns a literal representing s, enclosed in c.Delim and
+// escaped as c describes.
+func (c *QuoteConfig) Quote(s string) string {
+	return quoteWith(s, c)
+}
+
+// AppendQuote appends a literal representing s, as generated by c.Quote,
+// to dst and returns the extended buffer.
+func (c *QuoteConfig) AppendQuote(dst []byte, s string) []byte {
+	return appendQuotedWith(dst, s, c)
+}
+
+// AppendQuoteUTF8 is like AppendQuote, but also returns the number of
+// bytes of s that were not valid UTF-8 and so were escaped, replaced or
+// dropped according to c.InvalidUTF8. If c.InvalidUTF8 is
+// InvalidUTF8Error and s is not valid UTF-8, it returns dst, 0 and
+// ErrInvalidUTF8.
+func (c *QuoteConfig) AppendQuoteUTF8(dst []byte, s string) (buf []byte, repairs int, err error) {
+	buf, repairs = appendQuotedCount(dst, s, c)
+	if repairs > 0 && c.InvalidUTF8 == InvalidUTF8Error {
+		return dst, 0, ErrInvalidUTF8
+	}
+	return buf, repairs, nil
+}
+
+// QuoteRune returns a literal representing the rune, enclosed in c.Delim
+// and escaped as c describes.
+func (c *QuoteConfig) QuoteRune(r rune) string {
+	return quoteRuneWith(r, c)
+}
+
+// AppendQuoteRune appends a literal representing the rune, as generated
+// by c.QuoteRune, to dst and returns the extended buffer.
+func (c *QuoteConfig) AppendQuoteRune(dst []byte, r rune) []byte {
+	return appendQuotedRuneWith(dst, r, c)
+}
+
+func quoteWith(s string, c *QuoteConfig) string {
+	return string(appendQuotedWith(make([]byte, 0, 3*len(s)/2), s, c))
+}
+
+func quoteRuneWith(r rune, c *QuoteConfig) string {
+	return string(appendQuotedRuneWith(nil, r, c))
+}
+
+func appendQuotedWith(buf []byte, s string, c *QuoteConfig) []byte {
+	buf, _ = appendQuotedCount(buf, s, c)
+	return buf
+}
+
+// appendQuotedCount is appendQuotedWith, also returning the number of
+// invalid UTF-8 bytes in s.
+func appendQuotedCount(buf []byte, s string, c *QuoteConfig) ([]byte, int) {
+	invalid := 0
+	quote := c.delim()
 	buf = append(buf, quote)
+	hexEscape := false // whether buf ends with a C hex escape
 	for width := 0; len(s) > 0; s = s[width:] {
 		r := rune(s[0])
 		width = 1
 		if r >= utf8.RuneSelf {
 			r, width = utf8.DecodeRuneInString(s)
 		}
+		if c.Style == StyleC {
+			if hexEscape && isHexDigit(r) {
+				// A C hex escape consumes every hex digit that follows,
+				// so end the literal and start a new one.
+				buf = append(buf, quote, quote)
+			}
+			if r == '?' && buf[len(buf)-1] == '?' {
+				// Break up what could become a trigraph.
+				buf = append(buf, `\?`...)
+				hexEscape = false
+				continue
+			}
+		}
+		n := len(buf)
 		if width == 1 && r == utf8.RuneError {
-			buf = append(buf, `\x`...)
-			buf = append(buf, lowerhex[s[0]>>4])
-			buf = append(buf, lowerhex[s[0]&0xF])
-			continue
+			invalid++
+			switch c.InvalidUTF8 {
+			case InvalidUTF8Replace:
+				buf = appendEscapedRune(buf, r, quote, c)
+			case InvalidUTF8Drop:
+				continue
+			default:
+				buf = append(buf, `\x`...)
+				buf = append(buf, lowerhex[s[0]>>4])
+				buf = append(buf, lowerhex[s[0]&0xF])
+			}
+		} else {
+			buf = appendEscapedRune(buf, r, quote, c)
 		}
-		buf = appendEscapedRune(buf, r, quote, ASCIIonly, graphicOnly)
+		hexEscape = c.Style == StyleC && buf[n] == '\\' && buf[n+1] == 'x'
 	}
 	buf = append(buf, quote)
-	return buf
+	return buf, invalid
 }
 
-func appendQuotedRuneWith(buf []byte, r rune, quote byte, ASCIIonly, graphicOnly bool) []byte {
+func isHexDigit(r rune) bool {
+	return '0' <= r && r <= '9' || 'a' <= r && r <= 'f' || 'A' <= r && r <= 'F'
+}
+
+func appendQuotedRuneWith(buf []byte, r rune, c *QuoteConfig) []byte {
+	quote := c.delim()
 	buf = append(buf, quote)
 	if !utf8.ValidRune(r) {
 		r = utf8.RuneError
 	}
-	buf = appendEscapedRune(buf, r, quote, ASCIIonly, graphicOnly)
+	if c.Style == StyleC && 0x80 <= r && r < 0xA0 {
+		// A C character literal holds a single character, so write the
+		// value rather than its UTF-8 bytes, which appendEscapedRune
+		// uses for these runes.
+		buf = append(buf, `\x`...)
+		buf = append(buf, lowerhex[r>>4])
+		buf = append(buf, lowerhex[r&0xF])
+	} else {
+		buf = appendEscapedRune(buf, r, quote, c)
+	}
 	buf = append(buf, quote)
 	return buf
 }
 
-func appendEscapedRune(buf []byte, r rune, quote byte, ASCIIonly, graphicOnly bool) []byte {
+func appendEscapedRune(buf []byte, r rune, quote byte, c *QuoteConfig) []byte {
 	var runeTmp [utf8.UTFMax]byte
 	if r == rune(quote) || r == '\\' { // always backslashed
 		buf = append(buf, '\\')
 		buf = append(buf, byte(r))
 		return buf
 	}
-	if ASCIIonly {
+	extra := c.Escape.Contains(r)
+	if extra {
+		// Skip the unescaped cases.
+	} else if c.ASCIIOnly {
 		if r < utf8.RuneSelf && IsPrint(r) {
 			buf = append(buf, byte(r))
 			return buf
 		}
-	} else if IsPrint(r) || graphicOnly && isInGraphicList(r) {
+	} else if IsPrint(r) || c.GraphicOnly && isInGraphicList(r) {
 		n := utf8.EncodeRune(runeTmp[:], r)
 		buf = append(buf, runeTmp[:n]...)
 		return buf
@@ -84,10 +306,28 @@ func appendEscapedRune(buf []byte, r rune, quote byte, ASCIIonly, graphicOnly bo
 		buf = append(buf, `\v`...)
 	default:
 		switch {
-		case r < ' ':
-			buf = append(buf, `\x`...)
-			buf = append(buf, lowerhex[byte(r)>>4])
-			buf = append(buf, lowerhex[byte(r)&0xF])
+		case r < ' ' || extra || c.Style == StyleC && r < 0xA0:
+			// C only allows universal character names from U+00A0 on,
+			// so write the other control characters as UTF-8 bytes.
+			n := utf8.EncodeRune(runeTmp[:], r)
+			for _, b := range runeTmp[:n] {
+				buf = append(buf, `\x`...)
+				buf = append(buf, lowerhex[b>>4])
+				buf = append(buf, lowerhex[b&0xF])
+			}
+		case c.Style == StyleBrace:
+			if r > utf8.MaxRune {
+				r = 0xFFFD
+			}
+			buf = append(buf, `\u{`...)
+			s := 20
+			for s > 0 && r>>uint(s) == 0 {
+				s -= 4
+			}
+			for ; s >= 0; s -= 4 {
+				buf = append(buf, lowerhex[r>>uint(s)&0xF])
+			}
+			buf = append(buf, '}')
 		case r > utf8.MaxRune:
 			r = 0xFFFD
 			fallthrough
@@ -111,52 +351,52 @@ func appendEscapedRune(buf []byte, r rune, quote byte, ASCIIonly, graphicOnly bo
 // control characters and non-printable characters as defined by
 // IsPrint.
 func Quote(s string) string {
-	return quoteWith(s, '"', false, false)
+	return quoteWith(s, &goQuote)
 }
 
 // AppendQuote appends a double-quoted Go string literal representing s,
 // as generated by Quote, to dst and returns the extended buffer.
 func AppendQuote(dst []byte, s string) []byte {
-	return appendQuotedWith(dst, s, '"', false, false)
+	return appendQuotedWith(dst, s, &goQuote)
 }
 
 // QuoteToASCII returns a double-quoted Go string literal representing s.
 // The returned string uses Go escape sequences (\t, \n, \xFF, \u0100) for
 // non-ASCII characters and non-printable characters as defined by IsPrint.
 func QuoteToASCII(s string) string {
-	return quoteWith(s, '"', true, false)
+	return quoteWith(s, &goQuoteASCII)
 }
 
 // AppendQuoteToASCII appends a double-quoted Go string literal representing s,
 // as generated by QuoteToASCII, to dst and returns the extended buffer.
 func AppendQuoteToASCII(dst []byte, s string) []byte {
-	return appendQuotedWith(dst, s, '"', true, false)
+	return appendQuotedWith(dst, s, &goQuoteASCII)
 }
 
 // QuoteToGraphic returns a double-quoted Go string literal representing s.
 // The returned string uses Go escape sequences (\t, \n, \xFF, \u0100) for
 // non-ASCII characters and non-printable characters as defined by IsGraphic.
 func QuoteToGraphic(s string) string {
-	return quoteWith(s, '"', false, true)
+	return quoteWith(s, &goQuoteGraphic)
 }
 
 // AppendQuoteToGraphic appends a double-quoted Go string literal representing s,
 // as generated by QuoteToGraphic, to dst and returns the extended buffer.
 func AppendQuoteToGraphic(dst []byte, s string) []byte {
-	return appendQuotedWith(dst, s, '"', false, true)
+	return appendQuotedWith(dst, s, &goQuoteGraphic)
 }
 
 // QuoteRune returns a single-quoted Go character literal representing the
 // rune. The returned string uses Go escape sequences (\t, \n, \xFF, \u0100)
 // for control characters and non-printable characters as defined by IsPrint.
 func QuoteRune(r rune) string {
-	return quoteRuneWith(r, '\'', false, false)
+	return quoteRuneWith(r, &goRune)
 }
 
 // AppendQuoteRune appends a single-quoted Go character literal representing the rune,
 // as generated by QuoteRune, to dst and returns the extended buffer.
 func AppendQuoteRune(dst []byte, r rune) []byte {
-	return appendQuotedRuneWith(dst, r, '\'', false, false)
+	return appendQuotedRuneWith(dst, r, &goRune)
 }
 
 // QuoteRuneToASCII returns a single-quoted Go character literal representing
@@ -164,13 +404,13 @@ func AppendQuoteRune(dst []byte, r rune) []byte {
 // \u0100) for non-ASCII characters and non-printable characters as defined
 // by IsPrint.
 func QuoteRuneToASCII(r rune) string {
-	return quoteRuneWith(r, '\'', true, false)
+	return quoteRuneWith(r, &goRuneASCII)
 }
 
 // AppendQuoteRuneToASCII appends a single-quoted Go character literal representing the rune,
 // as generated by QuoteRuneToASCII, to dst and returns the extended buffer.
 func AppendQuoteRuneToASCII(dst []byte, r rune) []byte {
-	return appendQuotedRuneWith(dst, r, '\'', true, false)
+	return appendQuotedRuneWith(dst, r, &goRuneASCII)
 }
 
 // QuoteRuneToGraphic returns a single-quoted Go character literal representing
@@ -178,13 +418,45 @@ func AppendQuoteRuneToASCII(dst []byte, r rune) []byte {
 // \u0100) for non-ASCII characters and non-printable characters as defined
 // by IsGraphic.
 func QuoteRuneToGraphic(r rune) string {
-	return quoteRuneWith(r, '\'', false, true)
+	return quoteRuneWith(r, &goRuneGraphic)
 }
 
 // AppendQuoteRuneToGraphic appends a single-quoted Go character literal representing the rune,
 // as generated by QuoteRuneToGraphic, to dst and returns the extended buffer.
 func AppendQuoteRuneToGraphic(dst []byte, r rune) []byte {
-	return appendQuotedRuneWith(dst, r, '\'', false, true)
+	return appendQuotedRuneWith(dst, r, &goRuneGraphic)
+}
+
+// QuoteC returns a double-quoted C string literal representing s. The
+// returned string uses C11 escape sequences (\t, \n, \xff, \u0100) for
+// control characters and non-printable characters as defined by IsPrint,
+// and \? to keep a question mark from completing a trigraph. Because a C
+// hex escape extends over all the hex digits that follow it, a hex escape
+// followed by a hex digit ends the literal and a new one begins, as in
+// "\xff""f"; C concatenates adjacent string literals.
+func QuoteC(s string) string {
+	return quoteWith(s, &cQuote)
+}
+
+// AppendQuoteC appends a double-quoted C string literal representing s,
+// as generated by QuoteC, to dst and returns the extended buffer.
+func AppendQuoteC(dst []byte, s string) []byte {
+	return appendQuotedWith(dst, s, &cQuote)
+}
+
+// QuoteRuneC returns a single-quoted C character literal representing
+// the rune, using the escape sequences described for QuoteC, except that
+// the runes U+0080 through U+009F, which C does not allow as universal
+// character names, are written as a single \x escape of their value, as
+// in '\x85', rather than as UTF-8 bytes.
+func QuoteRuneC(r rune) string {
+	return quoteRuneWith(r, &cRune)
+}
+
+// AppendQuoteRuneC appends a single-quoted C character literal representing the rune,
+// as generated by QuoteRuneC, to dst and returns the extended buffer.
+func AppendQuoteRuneC(dst []byte, r rune) []byte {
+	return appendQuotedRuneWith(dst, r, &cRune)
 }
 
 // CanBackquote reports whether the string s can be represented
@@ -223,6 +495,28 @@ func unhex(b byte) (v rune, ok bool) {
 	return
 }
 
+// unhexBrace decodes the braced hexadecimal digits of a StyleBrace
+// Unicode escape at the start of s. It fails if the braces are empty,
+// unterminated or hold more than six digits, or if the value is not a
+// valid rune.
+func unhexBrace(s string) (v rune, tail string, ok bool) {
+	if len(s) == 0 || s[0] != '{' {
+		return
+	}
+	i := 1
+	for ; i < len(s) && s[i] != '}'; i++ {
+		x, ok := unhex(s[i])
+		if !ok || i > 6 {
+			return 0, "", false
+		}
+		v = v<<4 | x
+	}
+	if i == 1 || i == len(s) || !utf8.ValidRune(v) {
+		return 0, "", false
+	}
+	return v, s[i+1:], true
+}
+
 // UnquoteChar decodes the first character or byte in the escaped string
 // or character literal represented by the string s.
 // It returns four values:
@@ -238,6 +532,23 @@ func unhex(b byte) (v rune, ok bool) {
 // If set to a double quote, it permits \" and disallows unescaped ".
 // If set to zero, it does not permit either escape and allows both quote characters to appear unescaped.
 func UnquoteChar(s string, quote byte) (value rune, multibyte bool, tail string, err error) {
+	return unquoteChar(s, quote, StyleGo)
+}
+
+// UnquoteCharStyle is like UnquoteChar, but decodes the escape sequences
+// of the given style. For StyleC, these are the C11 escapes: \x takes one
+// or more hexadecimal digits and an octal escape one to three octal
+// digits, both for a value of at most 255; \? stands for a question mark;
+// \' and \" are permitted regardless of quote; and \U and \u must
+// denote a valid rune that is U+00A0 or above, or one of $, @ and `.
+// For StyleBrace, a Unicode escape is \u followed by one to six
+// hexadecimal digits in braces, which must denote a valid rune that is
+// not a surrogate; \U is not permitted.
+func UnquoteCharStyle(s string, quote byte, style QuoteStyle) (value rune, multibyte bool, tail string, err error) {
+	return unquoteChar(s, quote, style)
+}
+
+func unquoteChar(s string, quote byte, style QuoteStyle) (value rune, multibyte bool, tail string, err error) {
 	// easy cases
 	if len(s) == 0 {
 		err = ErrSyntax
@@ -278,6 +589,17 @@ func UnquoteChar(s string, quote byte) (value rune, multibyte bool, tail string,
 	case 'v':
 		value = '\v'
 	case 'x', 'u', 'U':
+		if c != 'x' && style == StyleBrace {
+			v, t, ok := unhexBrace(s)
+			if c == 'U' || !ok {
+				err = ErrSyntax
+				return
+			}
+			s = t
+			value = v
+			multibyte = true
+			break
+		}
 		n := 0
 		switch c {
 		case 'x':
@@ -287,6 +609,17 @@ func UnquoteChar(s string, quote byte) (value rune, multibyte bool, tail string,
 		case 'U':
 			n = 8
 		}
+		if c == 'x' && style == StyleC {
+			// A C hex escape takes all the hex digits that follow.
+			n = 0
+			for n < len(s) && isHexDigit(rune(s[n])) {
+				n++
+			}
+			if n == 0 {
+				err = ErrSyntax
+				return
+			}
+		}
 		var v rune
 		if len(s) < n {
 			err = ErrSyntax
@@ -299,6 +632,10 @@ func UnquoteChar(s string, quote byte) (value rune, multibyte bool, tail string,
 				return
 			}
 			v = v<<4 | x
+			if c == 'x' && v > 255 {
+				err = ErrSyntax
+				return
+			}
 		}
 		s = s[n:]
 		if c == 'x' {
@@ -310,15 +647,27 @@ func UnquoteChar(s string, quote byte) (value rune, multibyte bool, tail string,
 			err = ErrSyntax
 			return
 		}
+		if style == StyleC && (!utf8.ValidRune(v) || v < 0xA0 && v != '$' && v != '@' && v != '`') {
+			err = ErrSyntax
+			return
+		}
 		value = v
 		multibyte = true
 	case '0', '1', '2', '3', '4', '5', '6', '7':
 		v := rune(c) - '0'
-		if len(s) < 2 {
+		n := 2 // one digit already; two more
+		if style == StyleC {
+			// C takes up to two more.
+			n = 0
+			for n < 2 && n < len(s) && '0' <= s[n] && s[n] <= '7' {
+				n++
+			}
+		}
+		if len(s) < n {
 			err = ErrSyntax
 			return
 		}
-		for j := 0; j < 2; j++ { // one digit already; two more
+		for j := 0; j < n; j++ {
 			x := rune(s[j]) - '0'
 			if x < 0 || x > 7 {
 				err = ErrSyntax
@@ -326,7 +675,7 @@ func UnquoteChar(s string, quote byte) (value rune, multibyte bool, tail string,
 			}
 			v = (v << 3) | x
 		}
-		s = s[2:]
+		s = s[n:]
 		if v > 255 {
 			err = ErrSyntax
 			return
@@ -334,8 +683,14 @@ func UnquoteChar(s string, quote byte) (value rune, multibyte bool, tail string,
 		value = v
 	case '\\':
 		value = '\\'
+	case '?':
+		if style != StyleC {
+			err = ErrSyntax
+			return
+		}
+		value = '?'
 	case '\'', '"':
-		if c != quote {
+		if c != quote && style != StyleC {
 			err = ErrSyntax
 			return
 		}
@@ -348,12 +703,53 @@ func UnquoteChar(s string, quote byte) (value rune, multibyte bool, tail string,
 	return
 }
 
+// UnquoteCharUTF16 is like UnquoteChar, but decodes \u escapes as UTF-16
+// code units, the way JavaScript, Java and JSON write characters outside
+// the Basic Multilingual Plane: a \u escape of a high surrogate
+// (U+D800 to U+DBFF) immediately followed by a \u escape of a low
+// surrogate (U+DC00 to U+DFFF) decodes to the single rune the pair
+// encodes, and tail skips both escapes. Any other escape of a surrogate,
+// including one written with \U, yields ErrUnpairedSurrogate.
+func UnquoteCharUTF16(s string, quote byte) (value rune, multibyte bool, tail string, err error) {
+	value, multibyte, tail, err = UnquoteChar(s, quote)
+	if err != nil || !multibyte || !utf16.IsSurrogate(value) {
+		return
+	}
+	if s[1] == 'u' && value < 0xDC00 && len(tail) >= 2 && tail[0] == '\\' && tail[1] == 'u' {
+		lo, _, t, err := UnquoteChar(tail, quote)
+		if err == nil && 0xDC00 <= lo && lo <= 0xDFFF {
+			return utf16.DecodeRune(value, lo), true, t, nil
+		}
+	}
+	return 0, false, "", ErrUnpairedSurrogate
+}
+
 // Unquote interprets s as a single-quoted, double-quoted,
 // or backquoted Go string literal, returning the string value
 // that s quotes.  (If s is single-quoted, it would be a Go
 // character literal; Unquote returns the corresponding
 // one-character string.)
 func Unquote(s string) (string, error) {
+	return unquote(s, UnquoteChar)
+}
+
+// UnquoteUTF16 is like Unquote, but decodes escaped UTF-16 surrogate
+// pairs as described for UnquoteCharUTF16.
+func UnquoteUTF16(s string) (string, error) {
+	return unquote(s, UnquoteCharUTF16)
+}
+
+// UnquoteBrace is like Unquote, but decodes escape sequences as
+// UnquoteCharStyle does with StyleBrace.
+func UnquoteBrace(s string) (string, error) {
+	return unquote(s, unquoteCharBrace)
+}
+
+func unquoteCharBrace(s string, quote byte) (value rune, multibyte bool, tail string, err error) {
+	return unquoteChar(s, quote, StyleBrace)
+}
+
+func unquote(s string, unquoteChar func(string, byte) (rune, bool, string, error)) (string, error) {
 	n := len(s)
 	if n < 2 {
 		return "", ErrSyntax
@@ -405,7 +801,7 @@ func Unquote(s string) (string, error) {
 	var runeTmp [utf8.UTFMax]byte
 	buf := make([]byte, 0, 3*len(s)/2) // Try to avoid more allocations.
 	for len(s) > 0 {
-		c, multibyte, ss, err := UnquoteChar(s, quote)
+		c, multibyte, ss, err := unquoteChar(s, quote)
 		if err != nil {
 			return "", err
 		}
@@ -424,6 +820,120 @@ func Unquote(s string) (string, error) {
 	return string(buf), nil
 }
 
+// QuotedPrefix returns the quoted Go string or character literal at the
+// start of ba, which may be followed by anything else. The literal may be
+// double-quoted, single-quoted or backquoted; an escaped quote does not
+// end it. The literal is validated as by Unquote, whose error it returns.
+// The result is a subslice of ba.
+func QuotedPrefix(ba []byte) ([]byte, error) {
+	if len(ba) == 0 {
+		return nil, ErrSyntax
+	}
+	quote := ba[0]
+	switch quote {
+	case '`':
+		i := bytes.IndexByte(ba[1:], '`')
+		if i < 0 {
+			return nil, ErrSyntax
+		}
+		return ba[:i+2], nil
+	case '"', '\'':
+	default:
+		return nil, ErrSyntax
+	}
+
+	// Walk the literal as unquote does, but without building its value.
+	// unquoteChar only needs to see one character or escape, at most
+	// len(`\U0010FFFF`) bytes, which it can get without an allocation.
+	const maxChar = 10
+	for i, n := 1, 0; i < len(ba); n++ {
+		switch ba[i] {
+		case quote:
+			if quote == '\'' && n != 1 {
+				// single-quoted must be single character
+				return nil, ErrSyntax
+			}
+			return ba[:i+1], nil
+		case '\n':
+			return nil, ErrSyntax
+		}
+		c := ba[i:min(i+maxChar, len(ba))]
+		_, _, tail, err := unquoteChar(string(c), quote, StyleGo)
+		if err != nil {
+			return nil, err
+		}
+		i += len(c) - len(tail)
+	}
+	return nil, ErrSyntax
+}
+
+// UnquoteC interprets s as a C character literal or as a sequence of C
+// string literals separated by white space, which C concatenates, and
+// returns the string value that s quotes. Escape sequences are decoded as
+// described for UnquoteCharStyle with StyleC. Prefixed literals such as
+// L"..." and u8"..." are not supported.
+func UnquoteC(s string) (string, error) {
+	if len(s) > 0 && s[0] == '\'' {
+		n := len(s)
+		if n < 3 || s[n-1] != '\'' || contains(s, '\n') {
+			return "", ErrSyntax
+		}
+		c, multibyte, tail, err := unquoteChar(s[1:n-1], '\'', StyleC)
+		if err != nil || tail != "" {
+			return "", ErrSyntax
+		}
+		if c < utf8.RuneSelf || !multibyte {
+			return string([]byte{byte(c)}), nil
+		}
+		return string(c), nil
+	}
+
+	var runeTmp [utf8.UTFMax]byte
+	buf := make([]byte, 0, 3*len(s)/2) // Try to avoid more allocations.
+	for first := true; ; first = false {
+		s = trimCSpace(s)
+		if len(s) == 0 && !first {
+			return string(buf), nil
+		}
+		if len(s) == 0 || s[0] != '"' {
+			return "", ErrSyntax
+		}
+		for s = s[1:]; ; {
+			if len(s) == 0 || s[0] == '\n' {
+				return "", ErrSyntax
+			}
+			if s[0] == '"' {
+				s = s[1:]
+				break
+			}
+			c, multibyte, ss, err := unquoteChar(s, '"', StyleC)
+			if err != nil {
+				return "", err
+			}
+			s = ss
+			if c < utf8.RuneSelf || !multibyte {
+				buf = append(buf, byte(c))
+			} else {
+				n := utf8.EncodeRune(runeTmp[:], c)
+				buf = append(buf, runeTmp[:n]...)
+			}
+		}
+	}
+}
+
+// trimCSpace returns s without its leading C white space.
+func trimCSpace(s string) string {
+	for len(s) > 0 {
+		switch s[0] {
+		case ' ', '\t', '\n', '\v', '\f', '\r':
+			s = s[1:]
+		default:
+			return s
+		}
+	}
+	return s
+}
+
 // contains reports whether the string contains the byte c.
 func contains(s string, c byte) bool {
 	for i := 0; i < len(s); i++ {
@@ -527,6 +1037,64 @@ func IsGraphic(r rune) bool {
 	return isInGraphicList(r)
 }
 
+// printableASCII8 reports whether all eight bytes packed in chunk
+// are printable ASCII, from space through tilde.
+func printableASCII8(chunk uint64) bool {
+	const (
+		lo7 = 0x0101010101010101
+		hi  = 0x8080808080808080
+	)
+	if chunk&hi != 0 {
+		return false // non-ASCII
+	}
+	// With all bytes below 0x80, (b - n) & ^b & 0x80 is set
+	// exactly for the bytes b < n.
+	below := (chunk - lo7*' ') &^ chunk & hi
+	del := (chunk ^ lo7*0x7F - lo7) &^ (chunk ^ lo7*0x7F) & hi
+	return below|del == 0
+}
+
+// validRunes reports whether every rune in ba satisfies ok, and if not,
+// the byte offset of the first one that does not. Invalid UTF-8 is
+// never valid. Printable ASCII, which satisfies both IsPrint and
+// IsGraphic, is checked eight bytes at a time.
+func validRunes(ba []byte, ok func(rune) bool) (bool, int) {
+	for i := 0; i < len(ba); {
+		if len(ba)-i >= 8 && printableASCII8(binary.LittleEndian.Uint64(ba[i:])) {
+			i += 8
+			continue
+		}
+		r, width := rune(ba[i]), 1
+		if r >= utf8.RuneSelf {
+			r, width = utf8.DecodeRune(ba[i:])
+			if width == 1 {
+				return false, i // invalid UTF-8
+			}
+		}
+		if !ok(r) {
+			return false, i
+		}
+		i += width
+	}
+	return true, -1
+}
+
+// ValidPrint reports whether ba is valid UTF-8 consisting entirely of
+// runes for which IsPrint is true. If not, it also returns the byte
+// offset of the first offending rune or invalid byte; otherwise the
+// offset is -1.
+func ValidPrint(ba []byte) (ok bool, badOffset int) {
+	return validRunes(ba, IsPrint)
+}
+
+// ValidGraphic reports whether ba is valid UTF-8 consisting entirely of
+// runes for which IsGraphic is true. If not, it also returns the byte
+// offset of the first offending rune or invalid byte; otherwise the
+// offset is -1.
+func ValidGraphic(ba []byte) (ok bool, badOffset int) {
+	return validRunes(ba, IsGraphic)
+}
+
 // isInGraphicList reports whether the rune is in the isGraphic list. This separation
 // from IsGraphic allows quoteWith to avoid two calls to IsPrint.
 // Should be called only if IsPrint fails.
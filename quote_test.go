@@ -7,8 +7,16 @@ package baconv
 import (
 	"testing"
 	"unicode"
+	"unicode/utf8"
 )
 
+// Verify that isprint.go was generated from the toolchain's Unicode tables.
+func TestUnicodeVersion(t *testing.T) {
+	if unicodeVersion != unicode.Version {
+		t.Errorf("isprint.go is for Unicode %s, toolchain has %s; run go generate", unicodeVersion, unicode.Version)
+	}
+}
+
 // Verify that our IsPrint agrees with unicode.IsPrint.
 func TestIsPrint(t *testing.T) {
 	n := 0
@@ -37,6 +45,87 @@ func TestIsGraphic(t *testing.T) {
 	}
 }
 
+type validPrintTest struct {
+	in             string
+	print, graphic int // offset of the first bad rune, or -1
+}
+
+var validPrintTests = []validPrintTest{
+	{"", -1, -1},
+	{"hello, world", -1, -1},
+	{"Hello, 世界", -1, -1},
+	{"0123456789abcdefghijklmnopqrstuvwxyz ~", -1, -1},
+	{"\x00", 0, 0},
+	{"\x00abcdefgh", 0, 0},
+	{"abcdefgh\x7f", 8, 8},
+	{"abcdefg\x7fh", 7, 7},
+	{"abcdefgh\x1f", 8, 8},
+	{"abcdefgh\t", 8, 8},
+	{"abcdefghijklmno\n", 15, 15},
+	{"abcdefgh\u00a0ijklmnop", 8, -1},
+	{"abcdefgh\u2000ij\u3000", 8, -1},
+	{"abcdefgh\u00adij", 8, 8},
+	{"abcdefgh\xff", 8, 8},
+	{"abcdefgh\xe4\xb8", 8, 8},
+	{"世界\xe4\xb8", 6, 6},
+	{"\ufeff", 0, 0},
+	{"\U0001F600 smile", -1, -1},
+	{"\U0010ffff", 0, 0},
+}
+
+func TestValidPrint(t *testing.T) {
+	for _, tt := range validPrintTests {
+		ok, off := ValidPrint([]byte(tt.in))
+		if ok != (tt.print < 0) || off != tt.print {
+			t.Errorf("ValidPrint(%q) = %v, %d, want %v, %d", tt.in, ok, off, tt.print < 0, tt.print)
+		}
+		ok, off = ValidGraphic([]byte(tt.in))
+		if ok != (tt.graphic < 0) || off != tt.graphic {
+			t.Errorf("ValidGraphic(%q) = %v, %d, want %v, %d", tt.in, ok, off, tt.graphic < 0, tt.graphic)
+		}
+	}
+}
+
+// Verify that the ASCII fast path agrees with IsPrint at every position.
+func TestValidPrintASCII(t *testing.T) {
+	buf := []byte("abcdefghijklmnopqrstuvwx")
+	for i := range buf {
+		for c := 0; c < utf8.RuneSelf; c++ {
+			old := buf[i]
+			buf[i] = byte(c)
+			want := -1
+			if !IsPrint(rune(c)) {
+				want = i
+			}
+			if ok, off := ValidPrint(buf); ok != (want < 0) || off != want {
+				t.Errorf("ValidPrint(%q) = %v, %d, want %d", buf, ok, off, want)
+			}
+			buf[i] = old
+		}
+	}
+}
+
+func BenchmarkValidPrint(b *testing.B) {
+	ascii := []byte("The quick brown fox jumps over the lazy dog, 0123456789 times!")
+	b.Run("ASCII", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ok, _ := ValidPrint(ascii)
+			if !ok {
+				b.Fatal("not printable")
+			}
+		}
+	})
+	mixed := []byte("Příliš žluťoučký kůň úpěl ďábelské ódy, 你好世界")
+	b.Run("Mixed", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ok, _ := ValidPrint(mixed)
+			if !ok {
+				b.Fatal("not printable")
+			}
+		}
+	})
+}
+
 type quoteTest struct {
 	in      string
 	out     string
@@ -179,39 +268,39 @@ type canBackquoteTest struct {
 
 var canbackquotetests = []canBackquoteTest{
 	{"`", false},
-	{string(0), false},
-	{string(1), false},
-	{string(2), false},
-	{string(3), false},
-	{string(4), false},
-	{string(5), false},
-	{string(6), false},
-	{string(7), false},
-	{string(8), false},
-	{string(9), true}, // \t
-	{string(10), false},
-	{string(11), false},
-	{string(12), false},
-	{string(13), false},
-	{string(14), false},
-	{string(15), false},
-	{string(16), false},
-	{string(17), false},
-	{string(18), false},
-	{string(19), false},
-	{string(20), false},
-	{string(21), false},
-	{string(22), false},
-	{string(23), false},
-	{string(24), false},
-	{string(25), false},
-	{string(26), false},
-	{string(27), false},
-	{string(28), false},
-	{string(29), false},
-	{string(30), false},
-	{string(31), false},
-	{string(0x7F), false},
+	{string(rune(0)), false},
+	{string(rune(1)), false},
+	{string(rune(2)), false},
+	{string(rune(3)), false},
+	{string(rune(4)), false},
+	{string(rune(5)), false},
+	{string(rune(6)), false},
+	{string(rune(7)), false},
+	{string(rune(8)), false},
+	{string(rune(9)), true}, // \t
+	{string(rune(10)), false},
+	{string(rune(11)), false},
+	{string(rune(12)), false},
+	{string(rune(13)), false},
+	{string(rune(14)), false},
+	{string(rune(15)), false},
+	{string(rune(16)), false},
+	{string(rune(17)), false},
+	{string(rune(18)), false},
+	{string(rune(19)), false},
+	{string(rune(20)), false},
+	{string(rune(21)), false},
+	{string(rune(22)), false},
+	{string(rune(23)), false},
+	{string(rune(24)), false},
+	{string(rune(25)), false},
+	{string(rune(26)), false},
+	{string(rune(27)), false},
+	{string(rune(28)), false},
+	{string(rune(29)), false},
+	{string(rune(30)), false},
+	{string(rune(31)), false},
+	{string(rune(0x7F)), false},
 	{`' !"#$%&'()*+,-./:;<=>?@[\]^_{|}~`, true},
 	{`0123456789`, true},
 	{`ABCDEFGHIJKLMNOPQRSTUVWXYZ`, true},
@@ -325,6 +414,540 @@ func TestUnquote(t *testing.T) {
 	}
 }
 
+var unquoteUTF16Tests = []struct {
+	in  string
+	out string
+	err error
+}{
+	{`"\ud83d\ude00"`, "\U0001F600", nil},
+	{`"\uD83D\uDE00"`, "\U0001F600", nil},
+	{`'\ud83d\ude00'`, "\U0001F600", nil},
+	{`"a\ud800\udc00b"`, "a\U00010000b", nil},
+	{`"\udbff\udfff"`, "\U0010FFFF", nil},
+	{`"\ud83d\ude00\ud83d\ude01"`, "\U0001F600\U0001F601", nil},
+	{`"\u00e9\u4e16"`, "\u00e9\u4e16", nil},
+	{`"\U0001F600"`, "\U0001F600", nil},
+	{`"\ud83d"`, "", ErrUnpairedSurrogate},
+	{`"\ud83dx"`, "", ErrUnpairedSurrogate},
+	{`"\ud83d\u0041"`, "", ErrUnpairedSurrogate},
+	{`"\ud83d\ud83d\ude00"`, "", ErrUnpairedSurrogate},
+	{`"\ude00"`, "", ErrUnpairedSurrogate},
+	{`"\ude00\ud83d"`, "", ErrUnpairedSurrogate},
+	{`"\ud83d\\ude00"`, "", ErrUnpairedSurrogate},
+	{`"\U0000D83D\ude00"`, "", ErrUnpairedSurrogate},
+	{`"\ud83d\U0000DE00"`, "", ErrUnpairedSurrogate},
+	{`"\ud83d\ude0"`, "", ErrUnpairedSurrogate},
+	{`"\ud83`, "", ErrSyntax},
+	{`'\ud83d\ude00\ud83d\ude00'`, "", ErrSyntax},
+}
+
+func TestUnquoteUTF16(t *testing.T) {
+	for _, tt := range unquoteUTF16Tests {
+		if out, err := UnquoteUTF16(tt.in); out != tt.out || err != tt.err {
+			t.Errorf("UnquoteUTF16(%#q) = %q, %v, want %q, %v", tt.in, out, err, tt.out, tt.err)
+		}
+	}
+	for _, tt := range unquotetests {
+		if out, err := UnquoteUTF16(tt.in); err != nil || out != tt.out {
+			t.Errorf("UnquoteUTF16(%#q) = %q, %v want %q, nil", tt.in, out, err, tt.out)
+		}
+	}
+	for _, s := range misquoted {
+		if out, err := UnquoteUTF16(s); out != "" || err != ErrSyntax {
+			t.Errorf("UnquoteUTF16(%#q) = %q, %v want %q, %v", s, out, err, "", ErrSyntax)
+		}
+	}
+}
+
+func TestUnquoteCharUTF16(t *testing.T) {
+	v, mb, tail, err := UnquoteCharUTF16(`\ud83d\ude00rest`, '"')
+	if v != 0x1F600 || !mb || tail != "rest" || err != nil {
+		t.Errorf("UnquoteCharUTF16 = %U, %v, %q, %v, want U+1F600, true, %q, nil", v, mb, tail, err, "rest")
+	}
+	// UnquoteChar keeps decoding each half on its own.
+	v, mb, tail, err = UnquoteChar(`\ud83d\ude00rest`, '"')
+	if v != 0xD83D || !mb || tail != `\ude00rest` || err != nil {
+		t.Errorf("UnquoteChar = %U, %v, %q, %v, want U+D83D, true, %q, nil", v, mb, tail, err, `\ude00rest`)
+	}
+}
+
+type quoteCTest struct {
+	in  string
+	out string
+}
+
+var quoteCTests = []quoteCTest{
+	{"", `""`},
+	{"abc", `"abc"`},
+	{`a"b\c`, `"a\"b\\c"`},
+	{"it's", `"it's"`},
+	{"\a\b\f\n\r\t\v", `"\a\b\f\n\r\t\v"`},
+	{"\x00", `"\x00"`},
+	{"\x00z", `"\x00z"`},
+	{"\x001", `"\x00""1"`},
+	{"\x1bf", `"\x1b""f"`},
+	{"\x1bF\x1bg", `"\x1b""F\x1bg"`},
+	{"\x7f", `"\x7f"`},
+	{"\xff", `"\xff"`},
+	{"\xffab", `"\xff""ab"`},
+	{"\xc2\x85", `"\xc2\x85"`}, // U+0085 is below U+00A0
+	{"\u00ad", `"\u00ad"`},
+	{"\ufeff9", `"\ufeff9"`},
+	{"\U000e0001", `"\U000e0001"`},
+	{"\xe4\xb8\x96\xe7\x95\x8c", "\"\xe4\xb8\x96\xe7\x95\x8c\""},
+	{"??=", `"?\?="`},
+	{"???", `"?\?\?"`},
+	{"a?b?", `"a?b?"`},
+	{"$@`", "\"$@`\""},
+}
+
+func TestQuoteC(t *testing.T) {
+	for _, tt := range quoteCTests {
+		if out := QuoteC(tt.in); out != tt.out {
+			t.Errorf("QuoteC(%q) = %s, want %s", tt.in, out, tt.out)
+		}
+		if out := AppendQuoteC([]byte("abc"), tt.in); string(out) != "abc"+tt.out {
+			t.Errorf("AppendQuoteC(%q, %q) = %s, want %s", "abc", tt.in, out, "abc"+tt.out)
+		}
+		if out, err := UnquoteC(tt.out); err != nil || out != tt.in {
+			t.Errorf("UnquoteC(%s) = %q, %v, want %q, nil", tt.out, out, err, tt.in)
+		}
+	}
+}
+
+var quoteRuneCTests = []struct {
+	in  rune
+	out string
+}{
+	{'a', `'a'`},
+	{'\'', `'\''`},
+	{'"', `'"'`},
+	{'\\', `'\\'`},
+	{'?', `'?'`},
+	{0, `'\x00'`},
+	{0x7f, `'\x7f'`},
+	{0x80, `'\x80'`},
+	{0x85, `'\x85'`},
+	{0x9f, `'\x9f'`},
+	{0xad, `'\u00ad'`},
+	{0x263a, "'\xe2\x98\xba'"},
+	{0x0010ffff, `'\U0010ffff'`},
+	{0x04fffffff, "'\xef\xbf\xbd'"},
+}
+
+func TestQuoteRuneC(t *testing.T) {
+	for _, tt := range quoteRuneCTests {
+		if out := QuoteRuneC(tt.in); out != tt.out {
+			t.Errorf("QuoteRuneC(%U) = %s, want %s", tt.in, out, tt.out)
+		}
+		if out := AppendQuoteRuneC([]byte("abc"), tt.in); string(out) != "abc"+tt.out {
+			t.Errorf("AppendQuoteRuneC(%q, %U) = %s, want %s", "abc", tt.in, out, "abc"+tt.out)
+		}
+	}
+}
+
+var unquoteCTests = []unQuoteTest{
+	{`"a" "b"`, "ab"},
+	{"\"a\"\n\t \"b\"\"c\"", "abc"},
+	{` "a" `, "a"},
+	{`"\x41\x4a\x4B"`, "AJK"},
+	{`"\x0000041"`, "A"},
+	{`"\1\12\101\1012"`, "\x01\nAA2"},
+	{`"\0"`, "\x00"},
+	{`"\?\'\"'"`, "?'\"'"},
+	{`"\u00e9\U0001F600"`, "\xc3\xa9\xf0\x9f\x98\x80"},
+	{`"\u0024\u0040\u0060"`, "$@`"},
+	{`'a'`, "a"},
+	{`'"'`, `"`},
+	{`'\"'`, `"`},
+	{`'\''`, "'"},
+	{`'\?'`, "?"},
+	{`'\377'`, "\xff"},
+	{`'\xff'`, "\xff"},
+	{`'\u263a'`, "\xe2\x98\xba"},
+	{`'\xe2'`, "\xe2"},
+}
+
+var misquotedC = []string{
+	``,
+	` `,
+	`"`,
+	`"a`,
+	`a"`,
+	`"a" b`,
+	`"a" 'b'`,
+	`"a"b"`,
+	"`a`",
+	`''`,
+	`'ab'`,
+	`'\'`,
+	"'\n'",
+	"\"a\nb\"",
+	`"\e"`,
+	`"\x"`,
+	`"\xg"`,
+	`"\x100"`,
+	`"\400"`,
+	`"\u0041"`,
+	`"\u0085"`,
+	`"\ud800"`,
+	`"\U00110000"`,
+	`"\u12"`,
+	`"\8"`,
+	`L"a"`,
+}
+
+func TestUnquoteC(t *testing.T) {
+	for _, tt := range unquoteCTests {
+		if out, err := UnquoteC(tt.in); err != nil || out != tt.out {
+			t.Errorf("UnquoteC(%#q) = %q, %v, want %q, nil", tt.in, out, err, tt.out)
+		}
+	}
+	for _, s := range misquotedC {
+		if out, err := UnquoteC(s); out != "" || err != ErrSyntax {
+			t.Errorf("UnquoteC(%#q) = %q, %v, want %q, %v", s, out, err, "", ErrSyntax)
+		}
+	}
+}
+
+func TestUnquoteCharStyle(t *testing.T) {
+	// Go and C read the same escapes differently.
+	tests := []struct {
+		in    string
+		style QuoteStyle
+		value rune
+		tail  string
+		err   error
+	}{
+		{`\x4142`, StyleGo, 'A', "42", nil},
+		{`\x4142`, StyleC, 0, "", ErrSyntax},
+		{`\x41g`, StyleC, 'A', "g", nil},
+		{`\1012`, StyleGo, 'A', "2", nil},
+		{`\1012`, StyleC, 'A', "2", nil},
+		{`\12`, StyleGo, 0, "", ErrSyntax},
+		{`\12`, StyleC, '\n', "", nil},
+		{`\?`, StyleGo, 0, "", ErrSyntax},
+		{`\?`, StyleC, '?', "", nil},
+		{`\'`, StyleGo, 0, "", ErrSyntax},
+		{`\'`, StyleC, '\'', "", nil},
+		{`\u0041`, StyleGo, 'A', "", nil},
+		{`\u0041`, StyleC, 0, "", ErrSyntax},
+		{`\u0041`, StyleBrace, 0, "", ErrSyntax},
+		{`\u{41}1`, StyleBrace, 'A', "1", nil},
+		{`\u{41}1`, StyleGo, 0, "", ErrSyntax},
+	}
+	for _, tt := range tests {
+		value, _, tail, err := UnquoteCharStyle(tt.in, '"', tt.style)
+		if value != tt.value || tail != tt.tail || err != tt.err {
+			t.Errorf("UnquoteCharStyle(%#q, '\"', %d) = %q, %q, %v, want %q, %q, %v",
+				tt.in, tt.style, value, tail, err, tt.value, tt.tail, tt.err)
+		}
+	}
+}
+
+func FuzzQuoteC(f *testing.F) {
+	for _, tt := range quoteCTests {
+		f.Add(tt.in)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		q := QuoteC(s)
+		if out, err := UnquoteC(q); err != nil || out != s {
+			t.Fatalf("UnquoteC(QuoteC(%q) = %s) = %q, %v", s, q, out, err)
+		}
+	})
+}
+
+var quoteBraceTests = []quoteCTest{
+	{"", `""`},
+	{"abc\n", `"abc\n"`},
+	{"\x00\x1b", `"\x00\x1b"`},
+	{"\x7f", `"\u{7f}"`},
+	{"\xff", `"\xff"`},
+	{"\u00ad", `"\u{ad}"`},
+	{"\ufeff", `"\u{feff}"`},
+	{"\U000e0001", `"\u{e0001}"`},
+	{"\U0010fffe", `"\u{10fffe}"`},
+	{"\xe4\xb8\x96", "\"\xe4\xb8\x96\""},
+}
+
+var unquoteBraceTests = []unQuoteTest{
+	{`"\u{0}"`, "\x00"},
+	{`"\u{1F600}\u{1f600}"`, "\U0001F600\U0001F600"},
+	{`"\u{00e9}"`, "\u00e9"},
+	{`"\u{000041}"`, "A"},
+	{`"\u{10FFFF}"`, "\U0010FFFF"},
+	{`"\u{D7FF}\u{E000}"`, "\uD7FF\uE000"},
+	{`'\u{263a}'`, "\u263a"},
+	{`"\x41\101\a"`, "AA\a"},
+}
+
+var misquotedBrace = []string{
+	`"\u{}"`,
+	`"\u{"`,
+	`"\u{41"`,
+	`"\u41}"`,
+	`"\u{0000041}"`,
+	`"\u{110000}"`,
+	`"\u{D800}"`,
+	`"\u{dfff}"`,
+	`"\u{4g}"`,
+	`"\u{ 41}"`,
+	`"\u{-1}"`,
+	`"\u0041"`,
+	`"\U{41}"`,
+	`"\U00000041"`,
+}
+
+func TestQuoteBrace(t *testing.T) {
+	c := QuoteConfig{Style: StyleBrace}
+	for _, tt := range quoteBraceTests {
+		if out := c.Quote(tt.in); out != tt.out {
+			t.Errorf("QuoteConfig{Style: StyleBrace}.Quote(%q) = %s, want %s", tt.in, out, tt.out)
+		}
+		if out, err := UnquoteBrace(tt.out); err != nil || out != tt.in {
+			t.Errorf("UnquoteBrace(%s) = %q, %v, want %q, nil", tt.out, out, err, tt.in)
+		}
+	}
+	c = QuoteConfig{Delim: '\'', Style: StyleBrace, ASCIIOnly: true}
+	if out, want := c.QuoteRune(0x1F600), `'\u{1f600}'`; out != want {
+		t.Errorf("%+v.QuoteRune(%U) = %s, want %s", c, 0x1F600, out, want)
+	}
+}
+
+func TestUnquoteBrace(t *testing.T) {
+	for _, tt := range unquoteBraceTests {
+		if out, err := UnquoteBrace(tt.in); err != nil || out != tt.out {
+			t.Errorf("UnquoteBrace(%#q) = %q, %v, want %q, nil", tt.in, out, err, tt.out)
+		}
+	}
+	for _, s := range misquotedBrace {
+		if out, err := UnquoteBrace(s); out != "" || err != ErrSyntax {
+			t.Errorf("UnquoteBrace(%#q) = %q, %v, want %q, %v", s, out, err, "", ErrSyntax)
+		}
+	}
+}
+
+func FuzzQuoteBrace(f *testing.F) {
+	for _, tt := range quoteBraceTests {
+		f.Add(tt.in)
+	}
+	c := QuoteConfig{Style: StyleBrace}
+	f.Fuzz(func(t *testing.T, s string) {
+		q := c.Quote(s)
+		if out, err := UnquoteBrace(q); err != nil || out != s {
+			t.Fatalf("UnquoteBrace(%s) = %q, %v, want %q, nil", q, out, err, s)
+		}
+	})
+}
+
+func TestEscapeSet(t *testing.T) {
+	set := NewEscapeSet("|%\x00\x7f")
+	for r := rune(-1); r <= 0x100; r++ {
+		want := r == '|' || r == '%' || r == 0 || r == 0x7f
+		if set.Contains(r) != want {
+			t.Errorf("NewEscapeSet(%q).Contains(%U) = %v, want %v", "|%\x00\x7f", r, !want, want)
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("NewEscapeSet(%q) did not panic", "\xe9")
+		}
+	}()
+	NewEscapeSet("\xe9")
+}
+
+var quoteConfigTests = []struct {
+	c   QuoteConfig
+	in  string
+	out string
+}{
+	{QuoteConfig{}, "a\tb", `"a\tb"`},
+	{QuoteConfig{Delim: '\''}, `it's "x"`, `'it\'s "x"'`},
+	{QuoteConfig{Delim: '/'}, "a/b\\", `/a\/b\\/`},
+	{QuoteConfig{Escape: NewEscapeSet("|%")}, "a|b%c", `"a\x7cb\x25c"`},
+	{QuoteConfig{Escape: NewEscapeSet("\n\"")}, "\n\"", `"\n\""`},
+	{QuoteConfig{Escape: NewEscapeSet("|"), Style: StyleC}, "|a|g", `"\x7c""a\x7cg"`},
+	{QuoteConfig{ASCIIOnly: true, Style: StyleC}, "\u00e9\U0001F600", `"\u00e9\U0001f600"`},
+	{QuoteConfig{ASCIIOnly: true, Escape: NewEscapeSet("e")}, "\u00e9e", `"\u00e9\x65"`},
+	{QuoteConfig{GraphicOnly: true, Escape: NewEscapeSet(" ")}, "a b\u00a0c", "\"a\\x20b\u00a0c\""},
+}
+
+func TestQuoteConfig(t *testing.T) {
+	for _, tt := range quoteConfigTests {
+		if out := tt.c.Quote(tt.in); out != tt.out {
+			t.Errorf("%+v.Quote(%q) = %s, want %s", tt.c, tt.in, out, tt.out)
+		}
+		if out := tt.c.AppendQuote([]byte("abc"), tt.in); string(out) != "abc"+tt.out {
+			t.Errorf("%+v.AppendQuote(%q, %q) = %s, want %s", tt.c, "abc", tt.in, out, "abc"+tt.out)
+		}
+		if tt.c.Delim == 0 {
+			unquote := Unquote
+			if tt.c.Style == StyleC {
+				unquote = UnquoteC
+			}
+			if out, err := unquote(tt.out); err != nil || out != tt.in {
+				t.Errorf("Unquote(%s) = %q, %v, want %q, nil", tt.out, out, err, tt.in)
+			}
+		}
+	}
+}
+
+// Verify that the Quote functions are presets of QuoteConfig.
+func TestQuoteConfigPresets(t *testing.T) {
+	configs := []struct {
+		c         QuoteConfig
+		quote     func(string) string
+		quoteRune func(rune) string
+	}{
+		{QuoteConfig{}, Quote, QuoteRune},
+		{QuoteConfig{ASCIIOnly: true}, QuoteToASCII, QuoteRuneToASCII},
+		{QuoteConfig{GraphicOnly: true}, QuoteToGraphic, QuoteRuneToGraphic},
+		{QuoteConfig{Style: StyleC}, QuoteC, QuoteRuneC},
+	}
+	for _, cfg := range configs {
+		for _, tt := range quotetests {
+			if out, want := cfg.c.Quote(tt.in), cfg.quote(tt.in); out != want {
+				t.Errorf("%+v.Quote(%q) = %s, want %s", cfg.c, tt.in, out, want)
+			}
+		}
+		rc := cfg.c
+		rc.Delim = '\''
+		for _, tt := range quoterunetests {
+			if out, want := rc.QuoteRune(tt.in), cfg.quoteRune(tt.in); out != want {
+				t.Errorf("%+v.QuoteRune(%U) = %s, want %s", rc, tt.in, out, want)
+			}
+			if out, want := rc.AppendQuoteRune([]byte("abc"), tt.in), cfg.quoteRune(tt.in); string(out) != "abc"+want {
+				t.Errorf("%+v.AppendQuoteRune(%q, %U) = %s, want %s", rc, "abc", tt.in, out, "abc"+want)
+			}
+		}
+	}
+}
+
+func TestQuoteConfigInvalidDelim(t *testing.T) {
+	for _, delim := range []byte{'\\', ' ', '\n', 0x7f, 0x80, 0xff, 'n', 'x', 'u', 'U', 'a', 'Z', '0', '7', '9'} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("QuoteConfig{Delim: %#x}.Quote did not panic", delim)
+				}
+			}()
+			c := QuoteConfig{Delim: delim}
+			c.Quote("x")
+		}()
+	}
+	for _, delim := range []byte("!\"#$%&'()*+,-./:;<=>?@[]^_`{|}~") {
+		c := QuoteConfig{Delim: delim}
+		want := string(delim) + "a\\" + string(delim) + "b" + string(delim)
+		if out := c.Quote("a" + string(delim) + "b"); out != want {
+			t.Errorf("QuoteConfig{Delim: %q}.Quote = %s, want %s", delim, out, want)
+		}
+	}
+}
+
+var quoteUTF8Tests = []struct {
+	c       QuoteConfig
+	in      string
+	out     string
+	repairs int
+}{
+	{QuoteConfig{}, "abc", `"abc"`, 0},
+	{QuoteConfig{InvalidUTF8: InvalidUTF8Replace}, "\u4e16\u754c", "\"\u4e16\u754c\"", 0},
+	{QuoteConfig{}, "a\xe4\xb8", `"a\xe4\xb8"`, 2}, // truncated 世
+	{QuoteConfig{InvalidUTF8: InvalidUTF8Replace}, "a\xe4\xb8", "\"a\ufffd\ufffd\"", 2},
+	{QuoteConfig{InvalidUTF8: InvalidUTF8Drop}, "a\xe4\xb8", `"a"`, 2},
+	{QuoteConfig{InvalidUTF8: InvalidUTF8Replace}, "\xf0\x9f\x98!", "\"\ufffd\ufffd\ufffd!\"", 3}, // truncated U+1F600
+	{QuoteConfig{InvalidUTF8: InvalidUTF8Drop}, "\xf0\x9f\x98\xf0\x9f\x98\x80", "\"\U0001F600\"", 3},
+	{QuoteConfig{InvalidUTF8: InvalidUTF8Replace}, "\xc3", "\"\ufffd\"", 1},
+	{QuoteConfig{InvalidUTF8: InvalidUTF8Replace}, "\x80\xbf", "\"\ufffd\ufffd\"", 2}, // lone continuation bytes
+	{QuoteConfig{InvalidUTF8: InvalidUTF8Drop}, "\xc0\xaf/", `"/"`, 2},                // overlong /
+	{QuoteConfig{InvalidUTF8: InvalidUTF8Drop}, "\xed\xa0\x80", `""`, 3},              // surrogate U+D800
+	{QuoteConfig{InvalidUTF8: InvalidUTF8Replace, ASCIIOnly: true}, "\xe4\xb8\x96\xe4\xb8", `"\u4e16\ufffd\ufffd"`, 2},
+	{QuoteConfig{InvalidUTF8: InvalidUTF8Replace, Style: StyleBrace, ASCIIOnly: true}, "\xff", `"\u{fffd}"`, 1},
+	{QuoteConfig{InvalidUTF8: InvalidUTF8Drop, Style: StyleC}, "\x01\xffa", `"\x01""a"`, 1},
+	{QuoteConfig{InvalidUTF8: InvalidUTF8Drop, Style: StyleC}, "?\xff?", `"?\?"`, 1},
+	{QuoteConfig{InvalidUTF8: InvalidUTF8Error}, "abc", `"abc"`, 0},
+}
+
+func TestQuoteConfigUTF8(t *testing.T) {
+	for _, tt := range quoteUTF8Tests {
+		out, repairs, err := tt.c.AppendQuoteUTF8([]byte("abc"), tt.in)
+		if string(out) != "abc"+tt.out || repairs != tt.repairs || err != nil {
+			t.Errorf("%+v.AppendQuoteUTF8(%q, %q) = %s, %d, %v, want %s, %d, nil",
+				tt.c, "abc", tt.in, out, repairs, err, "abc"+tt.out, tt.repairs)
+		}
+		if out := tt.c.Quote(tt.in); out != tt.out {
+			t.Errorf("%+v.Quote(%q) = %s, want %s", tt.c, tt.in, out, tt.out)
+		}
+	}
+}
+
+func TestQuoteConfigUTF8Error(t *testing.T) {
+	c := QuoteConfig{InvalidUTF8: InvalidUTF8Error}
+	for _, in := range []string{"\xff", "a\xe4\xb8", "\xf0\x9f\x98", "ok\xc3"} {
+		out, repairs, err := c.AppendQuoteUTF8([]byte("abc"), in)
+		if string(out) != "abc" || repairs != 0 || err != ErrInvalidUTF8 {
+			t.Errorf("%+v.AppendQuoteUTF8(%q, %q) = %q, %d, %v, want %q, 0, %v",
+				c, "abc", in, out, repairs, err, "abc", ErrInvalidUTF8)
+		}
+		// Quote cannot fail, so it escapes.
+		if out, want := c.Quote(in), Quote(in); out != want {
+			t.Errorf("%+v.Quote(%q) = %s, want %s", c, in, out, want)
+		}
+	}
+}
+
+var quotedPrefixTests = []struct {
+	in  string
+	out string
+}{
+	{`""`, `""`},
+	{`"abc" rest`, `"abc"`},
+	{`"abc\"def" rest`, `"abc\"def"`},
+	{`"a\\" "b"`, `"a\\"`},
+	{`"\x22" x`, `"\x22"`},
+	{`"\u263a\n"\n`, `"\u263a\n"`},
+	{`'a'b`, `'a'`},
+	{`'\''`, `'\''`},
+	{`'"' '"'`, `'"'`},
+	{"`a\\`b`", "`a\\`"},
+	{"`multi\nline` rest", "`multi\nline`"},
+	{"``", "``"},
+}
+
+var misquotedPrefix = []string{
+	``,
+	`abc`,
+	` "a"`,
+	`"`,
+	`"abc`,
+	`"abc\"`,
+	`"a\qb" rest`,
+	"\"a\nb\"",
+	`'ab'`,
+	`'\"'`,
+	"`abc",
+}
+
+func TestQuotedPrefix(t *testing.T) {
+	for _, tt := range quotedPrefixTests {
+		out, err := QuotedPrefix([]byte(tt.in))
+		if err != nil || string(out) != tt.out {
+			t.Errorf("QuotedPrefix(%#q) = %#q, %v, want %#q, nil", tt.in, out, err, tt.out)
+		}
+	}
+	for _, tt := range unquotetests {
+		out, err := QuotedPrefix([]byte(tt.in + " tail"))
+		if err != nil || string(out) != tt.in {
+			t.Errorf("QuotedPrefix(%#q) = %#q, %v, want %#q, nil", tt.in+" tail", out, err, tt.in)
+		}
+	}
+	for _, s := range misquotedPrefix {
+		if out, err := QuotedPrefix([]byte(s)); out != nil || err != ErrSyntax {
+			t.Errorf("QuotedPrefix(%#q) = %#q, %v, want nil, %v", s, out, err, ErrSyntax)
+		}
+	}
+}
+
 // Issue 23685: invalid UTF-8 should not go through the fast path.
 func TestUnquoteInvalidUTF8(t *testing.T) {
 	tests := []struct {
@@ -0,0 +1,101 @@
+package baconv
+
+import (
+	"bytes"
+	"unicode"
+	"unicode/utf8"
+)
+
+// validCSVSeparator reports whether sep can separate CSV fields.
+func validCSVSeparator(sep byte) bool {
+	return sep != 0 && sep != '"' && sep != '\r' && sep != '\n' && sep < utf8.RuneSelf
+}
+
+// NeedsCSVQuote reports whether field must be quoted to appear in a CSV
+// record with fields separated by sep. It applies the same rules as
+// encoding/csv's Writer: fields containing sep, a quotation mark, a
+// carriage return or a line feed, fields starting with white space and
+// the field \. (which ends input in PostgreSQL) are quoted.
+// NeedsCSVQuote panics if sep is not a valid separator: NUL, the
+// quotation mark, CR, LF and non-ASCII bytes are not.
+func NeedsCSVQuote(field []byte, sep byte) bool {
+	if !validCSVSeparator(sep) {
+		panic("bconv: invalid CSV separator " + QuoteRune(rune(sep)))
+	}
+	if len(field) == 0 {
+		return false
+	}
+	if len(field) == 2 && field[0] == '\\' && field[1] == '.' {
+		return true
+	}
+	for _, c := range field {
+		if c == '\n' || c == '\r' || c == '"' || c == sep {
+			return true
+		}
+	}
+	r1 := rune(field[0])
+	if r1 >= utf8.RuneSelf {
+		r1, _ = utf8.DecodeRune(field)
+	}
+	return unicode.IsSpace(r1)
+}
+
+// AppendQuoteCSV appends field to dst as a CSV field, as defined by
+// RFC 4180, for a record with fields separated by sep, and returns the
+// extended buffer. If NeedsCSVQuote reports that field must be quoted,
+// it is enclosed in quotation marks and each quotation mark inside it is
+// doubled; otherwise it is appended unchanged. Line breaks are copied
+// as they are. AppendQuoteCSV panics if sep is not a valid separator.
+func AppendQuoteCSV(dst, field []byte, sep byte) []byte {
+	if !NeedsCSVQuote(field, sep) {
+		return append(dst, field...)
+	}
+	dst = append(dst, '"')
+	for i := bytes.IndexByte(field, '"'); i >= 0; i = bytes.IndexByte(field, '"') {
+		dst = append(dst, field[:i+1]...)
+		dst = append(dst, '"')
+		field = field[i+1:]
+	}
+	dst = append(dst, field...)
+	dst = append(dst, '"')
+	return dst
+}
+
+// UnquoteCSV interprets ba as a single CSV field, as defined by RFC 4180,
+// and returns its value. A quoted field must end with the quotation mark
+// and must double every quotation mark inside it; an unquoted field is
+// returned as it is but must not contain a quotation mark. UnquoteCSV
+// does not know the separator, so the caller must split the record first.
+//
+// Unless ba contains a doubled quotation mark, the result is a subslice
+// of ba and no memory is allocated.
+func UnquoteCSV(ba []byte) ([]byte, error) {
+	n := len(ba)
+	if n == 0 || ba[0] != '"' {
+		if bytes.IndexByte(ba, '"') >= 0 {
+			return nil, ErrSyntax
+		}
+		return ba, nil
+	}
+	if n < 2 || ba[n-1] != '"' {
+		return nil, ErrSyntax
+	}
+	ba = ba[1 : n-1]
+
+	// Is it trivial? Avoid allocation.
+	i := bytes.IndexByte(ba, '"')
+	if i < 0 {
+		return ba, nil
+	}
+
+	buf := make([]byte, 0, len(ba)-1)
+	for ; i >= 0; i = bytes.IndexByte(ba, '"') {
+		if i+1 == len(ba) || ba[i+1] != '"' {
+			return nil, ErrSyntax
+		}
+		buf = append(buf, ba[:i+1]...)
+		ba = ba[i+2:]
+	}
+	buf = append(buf, ba...)
+	return buf, nil
+}
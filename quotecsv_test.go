@@ -0,0 +1,165 @@
+package baconv
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+)
+
+var quoteCSVTests = []struct {
+	in  string
+	sep byte
+	out string
+}{
+	{"", ',', ""},
+	{"abc", ',', "abc"},
+	{"a b", ',', "a b"},
+	{"a,b", ',', `"a,b"`},
+	{"a,b", ';', "a,b"},
+	{"a;b", ';', `"a;b"`},
+	{"a\tb", '\t', "\"a\tb\""},
+	{`say "hi"`, ',', `"say ""hi"""`},
+	{`"`, ',', `""""`},
+	{`""`, ',', `""""""`},
+	{"a\nb", ',', "\"a\nb\""},
+	{"a\r\nb", ',', "\"a\r\nb\""},
+	{" lead", ',', `" lead"`},
+	{"\tlead", ',', "\"\tlead\""},
+	{"\u00a0lead", ',', "\"\u00a0lead\""},
+	{"trail ", ',', "trail "},
+	{`\.`, ',', `"\."`},
+	{`\.x`, ',', `\.x`},
+	{"Hello, \u4e16\u754c", ',', "\"Hello, \u4e16\u754c\""},
+}
+
+func TestAppendQuoteCSV(t *testing.T) {
+	for _, tt := range quoteCSVTests {
+		out := AppendQuoteCSV([]byte("x:"), []byte(tt.in), tt.sep)
+		if string(out) != "x:"+tt.out {
+			t.Errorf("AppendQuoteCSV(%q, %q) = %q, want %q", tt.in, tt.sep, out[2:], tt.out)
+		}
+		if need := NeedsCSVQuote([]byte(tt.in), tt.sep); need != (tt.out != tt.in) {
+			t.Errorf("NeedsCSVQuote(%q, %q) = %v, want %v", tt.in, tt.sep, need, !need)
+		}
+	}
+}
+
+// Verify that AppendQuoteCSV writes the same fields as encoding/csv.
+func TestAppendQuoteCSVWriter(t *testing.T) {
+	for _, tt := range quoteCSVTests {
+		for _, sep := range []byte{',', ';', '\t', '|'} {
+			var want bytes.Buffer
+			w := csv.NewWriter(&want)
+			w.Comma = rune(sep)
+			w.Write([]string{tt.in, "x"})
+			w.Flush()
+
+			got := AppendQuoteCSV(nil, []byte(tt.in), sep)
+			got = append(got, sep, 'x', '\n')
+			if string(got) != want.String() {
+				t.Errorf("AppendQuoteCSV(%q, %q) = %q, encoding/csv wrote %q", tt.in, sep, got, want.String())
+			}
+		}
+	}
+}
+
+func TestNeedsCSVQuotePanic(t *testing.T) {
+	for _, sep := range []byte{0, '"', '\r', '\n', 0x80, 0xff} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NeedsCSVQuote(%q, %q) did not panic", "a", sep)
+				}
+			}()
+			NeedsCSVQuote([]byte("a"), sep)
+		}()
+	}
+}
+
+var unquoteCSVTests = []struct {
+	in  string
+	out string
+}{
+	{"", ""},
+	{"abc", "abc"},
+	{" a b ", " a b "},
+	{`""`, ""},
+	{`"abc"`, "abc"},
+	{`"a,b"`, "a,b"},
+	{`""""`, `"`},
+	{`"say ""hi"""`, `say "hi"`},
+	{"\"a\r\nb\"", "a\r\nb"},
+}
+
+var misquotedCSV = []string{
+	`"`,
+	`"abc`,
+	`abc"`,
+	`a"b`,
+	`"a"b"`,
+	`"a""b`,
+	`"""`,
+	`"a" `,
+	` "a"`,
+}
+
+func TestUnquoteCSV(t *testing.T) {
+	for _, tt := range unquoteCSVTests {
+		out, err := UnquoteCSV([]byte(tt.in))
+		if err != nil || string(out) != tt.out {
+			t.Errorf("UnquoteCSV(%#q) = %q, %v, want %q, nil", tt.in, out, err, tt.out)
+		}
+	}
+	for _, tt := range quoteCSVTests {
+		out, err := UnquoteCSV([]byte(tt.out))
+		if err != nil || string(out) != tt.in {
+			t.Errorf("UnquoteCSV(%#q) = %q, %v, want %q, nil", tt.out, out, err, tt.in)
+		}
+	}
+	for _, s := range misquotedCSV {
+		if out, err := UnquoteCSV([]byte(s)); out != nil || err != ErrSyntax {
+			t.Errorf("UnquoteCSV(%#q) = %q, %v, want nil, %v", s, out, err, ErrSyntax)
+		}
+	}
+}
+
+func FuzzQuoteCSV(f *testing.F) {
+	for _, tt := range quoteCSVTests {
+		f.Add([]byte(tt.in), tt.sep)
+	}
+	f.Fuzz(func(t *testing.T, field []byte, sep byte) {
+		if !validCSVSeparator(sep) || bytes.IndexByte(field, '\r') >= 0 {
+			return // csv.Reader turns \r\n into \n
+		}
+		quoted := AppendQuoteCSV(nil, field, sep)
+		out, err := UnquoteCSV(quoted)
+		if err != nil || !bytes.Equal(out, field) {
+			t.Fatalf("UnquoteCSV(%q) = %q, %v, want %q, nil", quoted, out, err, field)
+		}
+
+		// A trailing empty field keeps the record from being a blank line.
+		r := csv.NewReader(strings.NewReader(string(quoted) + string(sep) + "\n"))
+		r.Comma = rune(sep)
+		record, err := r.Read()
+		if err != nil {
+			t.Fatalf("csv.Reader(%q): %v", quoted, err)
+		}
+		if len(record) != 2 || record[0] != string(field) {
+			t.Fatalf("csv.Reader(%q) = %q, want [%q \"\"]", quoted, record, field)
+		}
+	})
+}
+
+func BenchmarkAppendQuoteCSV(b *testing.B) {
+	for _, s := range []string{"plain field", `a "quoted", field`} {
+		field := []byte(s)
+		b.Run(s, func(b *testing.B) {
+			dst := make([]byte, 0, 64)
+			for i := 0; i < b.N; i++ {
+				dst = AppendQuoteCSV(dst[:0], field, ',')
+				BenchSink += len(dst)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package baconv
+
+import (
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// AppendQuoteJSON appends a JSON string, as defined by RFC 8259,
+// representing ba to dst and returns the extended buffer. The quotation
+// mark, the backslash and the control characters U+0000 through U+001F
+// are escaped, using the short forms \b, \f, \n, \r and \t where they
+// exist and \u00XX otherwise. All other runes are copied unchanged.
+// Invalid UTF-8 is replaced by U+FFFD, as in encoding/json.
+//
+// If htmlSafe is set, <, > and & are also escaped as \u003c, \u003e and
+// \u0026 so that the result can be embedded in HTML <script> tags, and
+// U+2028 and U+2029 as \u2028 and \u2029, which JavaScript does not
+// accept unescaped in string literals.
+func AppendQuoteJSON(dst, ba []byte, htmlSafe bool) []byte {
+	dst = append(dst, '"')
+	for width := 0; len(ba) > 0; ba = ba[width:] {
+		r := rune(ba[0])
+		width = 1
+		if r >= utf8.RuneSelf {
+			r, width = utf8.DecodeRune(ba)
+		}
+		if width == 1 && r == utf8.RuneError {
+			dst = append(dst, "\ufffd"...)
+			continue
+		}
+		dst = appendEscapedRuneJSON(dst, r, htmlSafe)
+	}
+	dst = append(dst, '"')
+	return dst
+}
+
+func appendEscapedRuneJSON(buf []byte, r rune, htmlSafe bool) []byte {
+	var runeTmp [utf8.UTFMax]byte
+	if r == '"' || r == '\\' { // always backslashed
+		buf = append(buf, '\\')
+		buf = append(buf, byte(r))
+		return buf
+	}
+	if r >= ' ' && !(htmlSafe && (r == '<' || r == '>' || r == '&' || r == '\u2028' || r == '\u2029')) {
+		if r < utf8.RuneSelf {
+			return append(buf, byte(r))
+		}
+		n := utf8.EncodeRune(runeTmp[:], r)
+		buf = append(buf, runeTmp[:n]...)
+		return buf
+	}
+	switch r {
+	case '\b':
+		buf = append(buf, `\b`...)
+	case '\f':
+		buf = append(buf, `\f`...)
+	case '\n':
+		buf = append(buf, `\n`...)
+	case '\r':
+		buf = append(buf, `\r`...)
+	case '\t':
+		buf = append(buf, `\t`...)
+	default:
+		buf = append(buf, `\u`...)
+		for s := 12; s >= 0; s -= 4 {
+			buf = append(buf, lowerhex[r>>uint(s)&0xF])
+		}
+	}
+	return buf
+}
+
+// unquoteCharJSON decodes the first character in the body of the JSON
+// string ba. It returns the decoded rune and the remainder of ba. A \u
+// escape of a UTF-16 high surrogate followed by one of a low surrogate
+// decodes to the rune they encode together; any other surrogate decodes
+// to U+FFFD, as in encoding/json.
+func unquoteCharJSON(ba []byte) (value rune, tail []byte, err error) {
+	switch c := ba[0]; {
+	case c >= utf8.RuneSelf:
+		r, size := utf8.DecodeRune(ba)
+		if r == utf8.RuneError && size == 1 {
+			return 0, nil, ErrSyntax
+		}
+		return r, ba[size:], nil
+	case c < ' ' || c == '"':
+		return 0, nil, ErrSyntax
+	case c != '\\':
+		return rune(c), ba[1:], nil
+	}
+
+	if len(ba) < 2 {
+		return 0, nil, ErrSyntax
+	}
+	c := ba[1]
+	ba = ba[2:]
+	switch c {
+	case '"', '\\', '/':
+		value = rune(c)
+	case 'b':
+		value = '\b'
+	case 'f':
+		value = '\f'
+	case 'n':
+		value = '\n'
+	case 'r':
+		value = '\r'
+	case 't':
+		value = '\t'
+	case 'u':
+		v, ok := unhex4(ba)
+		if !ok {
+			return 0, nil, ErrSyntax
+		}
+		ba = ba[4:]
+		if utf16.IsSurrogate(v) {
+			if len(ba) >= 6 && ba[0] == '\\' && ba[1] == 'u' {
+				if v2, ok := unhex4(ba[2:]); ok {
+					if r := utf16.DecodeRune(v, v2); r != utf8.RuneError {
+						return r, ba[6:], nil
+					}
+				}
+			}
+			v = utf8.RuneError
+		}
+		value = v
+	default:
+		return 0, nil, ErrSyntax
+	}
+	return value, ba, nil
+}
+
+// unhex4 decodes the four hexadecimal digits at the start of ba.
+func unhex4(ba []byte) (v rune, ok bool) {
+	if len(ba) < 4 {
+		return 0, false
+	}
+	for j := 0; j < 4; j++ {
+		x, ok := unhex(ba[j])
+		if !ok {
+			return 0, false
+		}
+		v = v<<4 | x
+	}
+	return v, true
+}
+
+// UnquoteJSON interprets ba as a JSON string, as defined by RFC 8259,
+// and returns the bytes it represents. Unlike Unquote, it accepts the
+// escape \/ and rejects the Go-only escapes \a, \v, \x, \U and octal.
+// Unescaped control characters and invalid UTF-8 are syntax errors.
+// A \u escape of an unpaired UTF-16 surrogate decodes to U+FFFD.
+//
+// If ba contains no escapes, the result is a subslice of ba and no
+// memory is allocated.
+func UnquoteJSON(ba []byte) ([]byte, error) {
+	n := len(ba)
+	if n < 2 || ba[0] != '"' || ba[n-1] != '"' {
+		return nil, ErrSyntax
+	}
+	ba = ba[1 : n-1]
+
+	// Is it trivial? Avoid allocation.
+	i := 0
+	for i < len(ba) {
+		c := ba[i]
+		if c == '\\' || c == '"' || c < ' ' {
+			break
+		}
+		if c < utf8.RuneSelf {
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRune(ba[i:])
+		if r == utf8.RuneError && size == 1 {
+			return nil, ErrSyntax
+		}
+		i += size
+	}
+	if i == len(ba) {
+		return ba, nil
+	}
+
+	var runeTmp [utf8.UTFMax]byte
+	buf := make([]byte, i, len(ba))
+	copy(buf, ba)
+	for ba = ba[i:]; len(ba) > 0; {
+		r, tail, err := unquoteCharJSON(ba)
+		if err != nil {
+			return nil, err
+		}
+		ba = tail
+		if r < utf8.RuneSelf {
+			buf = append(buf, byte(r))
+		} else {
+			n := utf8.EncodeRune(runeTmp[:], r)
+			buf = append(buf, runeTmp[:n]...)
+		}
+	}
+	return buf, nil
+}
@@ -0,0 +1,180 @@
+package baconv
+
+import (
+	"encoding/json"
+	"testing"
+	"unicode/utf8"
+)
+
+type quoteJSONTest struct {
+	in   string
+	out  string
+	html string
+}
+
+var quoteJSONTests = []quoteJSONTest{
+	{"", `""`, `""`},
+	{"hello", `"hello"`, `"hello"`},
+	{"\"quoted\" \\ /", `"\"quoted\" \\ /"`, `"\"quoted\" \\ /"`},
+	{"\b\f\n\r\t", `"\b\f\n\r\t"`, `"\b\f\n\r\t"`},
+	{"\x00\x01\x1f\x7f", `"\u0000\u0001\u001f` + "\x7f\"", `"\u0000\u0001\u001f` + "\x7f\""},
+	{"\a\v", `"\u0007\u000b"`, `"\u0007\u000b"`},
+	{"<a href='x'>&amp;</a>", `"<a href='x'>&amp;</a>"`, `"\u003ca href='x'\u003e\u0026amp;\u003c/a\u003e"`},
+	{"\u2028\u2029", "\"\u2028\u2029\"", `"\u2028\u2029"`},
+	{"Hello, \u4e16\u754c", "\"Hello, \u4e16\u754c\"", "\"Hello, \u4e16\u754c\""},
+	{"\U0001F600", "\"\U0001F600\"", "\"\U0001F600\""},
+	{"\ufeff\u00ad", "\"\ufeff\u00ad\"", "\"\ufeff\u00ad\""},
+	{"\xff\xe4\xb8", "\"\ufffd\ufffd\ufffd\"", "\"\ufffd\ufffd\ufffd\""},
+}
+
+func TestAppendQuoteJSON(t *testing.T) {
+	for _, tt := range quoteJSONTests {
+		if out := AppendQuoteJSON([]byte("x:"), []byte(tt.in), false); string(out) != "x:"+tt.out {
+			t.Errorf("AppendQuoteJSON(%q, false) = %s, want %s", tt.in, out[2:], tt.out)
+		}
+		if out := AppendQuoteJSON(nil, []byte(tt.in), true); string(out) != tt.html {
+			t.Errorf("AppendQuoteJSON(%q, true) = %s, want %s", tt.in, out, tt.html)
+		}
+	}
+}
+
+type unquoteJSONTest struct {
+	in  string
+	out string
+}
+
+var unquoteJSONTests = []unquoteJSONTest{
+	{`""`, ""},
+	{`"hello"`, "hello"},
+	{`"\"\\\/\b\f\n\r\t"`, "\"\\/\b\f\n\r\t"},
+	{`"\u0000\u00e9\u00E9\u4e16"`, "\x00\u00e9\u00e9\u4e16"},
+	{`"\ud83d\ude00"`, "\U0001F600"},
+	{`"\uD83D\uDE00x"`, "\U0001F600x"},
+	{`"\ud83d"`, "\ufffd"},
+	{`"\ude00"`, "\ufffd"},
+	{`"\ude00\ud83d"`, "\ufffd\ufffd"},
+	{`"\ud83dx"`, "\ufffdx"},
+	{`"\ud83d\u0041"`, "\ufffdA"},
+	{`"\ud83d\ud83d\ude00"`, "\ufffd\U0001F600"},
+	{"\"Hello, \u4e16\u754c\"", "Hello, \u4e16\u754c"},
+	{"\"\x7f\"", "\x7f"},
+}
+
+var misquotedJSON = []string{
+	``,
+	`"`,
+	`"a`,
+	`a"`,
+	`'a'`,
+	"`a`",
+	`"\"`,
+	`"""`,
+	`"a"b"`,
+	`"\a"`,
+	`"\v"`,
+	`"\x41"`,
+	`"\101"`,
+	`"\U0001F600"`,
+	`"\'"`,
+	`"\u12"`,
+	`"\u12g4"`,
+	"\"\t\"",
+	"\"\n\"",
+	"\"\x00\"",
+	"\"\xff\"",
+	"\"\xe4\xb8\"",
+}
+
+func TestUnquoteJSON(t *testing.T) {
+	for _, tt := range unquoteJSONTests {
+		out, err := UnquoteJSON([]byte(tt.in))
+		if err != nil || string(out) != tt.out {
+			t.Errorf("UnquoteJSON(%s) = %q, %v, want %q, nil", tt.in, out, err, tt.out)
+		}
+	}
+	for _, tt := range quoteJSONTests {
+		if !utf8.ValidString(tt.in) {
+			continue
+		}
+		for _, q := range []string{tt.out, tt.html} {
+			out, err := UnquoteJSON([]byte(q))
+			if err != nil || string(out) != tt.in {
+				t.Errorf("UnquoteJSON(%s) = %q, %v, want %q, nil", q, out, err, tt.in)
+			}
+		}
+	}
+	for _, s := range misquotedJSON {
+		if out, err := UnquoteJSON([]byte(s)); out != nil || err != ErrSyntax {
+			t.Errorf("UnquoteJSON(%q) = %q, %v, want nil, %v", s, out, err, ErrSyntax)
+		}
+	}
+}
+
+func FuzzAppendQuoteJSON(f *testing.F) {
+	for _, tt := range quoteJSONTests {
+		f.Add([]byte(tt.in))
+	}
+	f.Fuzz(func(t *testing.T, ba []byte) {
+		got := AppendQuoteJSON(nil, ba, true)
+		want, err := json.Marshal(string(ba))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != string(want) {
+			t.Fatalf("AppendQuoteJSON(%q, true) = %s, want %s", ba, got, want)
+		}
+		got = AppendQuoteJSON(nil, ba, false)
+		var s string
+		if err := json.Unmarshal(got, &s); err != nil {
+			t.Fatalf("AppendQuoteJSON(%q, false) = %s: %v", ba, got, err)
+		}
+		if utf8.Valid(ba) && s != string(ba) {
+			t.Fatalf("AppendQuoteJSON(%q, false) = %s, which decodes to %q", ba, got, s)
+		}
+	})
+}
+
+func FuzzUnquoteJSON(f *testing.F) {
+	for _, tt := range unquoteJSONTests {
+		f.Add([]byte(tt.in))
+	}
+	for _, s := range misquotedJSON {
+		f.Add([]byte(s))
+	}
+	f.Fuzz(func(t *testing.T, ba []byte) {
+		got, err := UnquoteJSON(ba)
+		var want string
+		werr := json.Unmarshal(ba, &want)
+		// json.Unmarshal also accepts surrounding white space.
+		quoted := len(ba) >= 2 && ba[0] == '"' && ba[len(ba)-1] == '"'
+		switch {
+		case err == nil && werr != nil:
+			t.Fatalf("UnquoteJSON(%q) = %q, but json.Unmarshal failed: %v", ba, got, werr)
+		case err != nil && werr == nil && quoted && utf8.Valid(ba):
+			t.Fatalf("UnquoteJSON(%q) failed: %v, but json.Unmarshal = %q", ba, err, want)
+		case err == nil && string(got) != want:
+			t.Fatalf("UnquoteJSON(%q) = %q, want %q", ba, got, want)
+		}
+	})
+}
+
+func BenchmarkAppendQuoteJSON(b *testing.B) {
+	ba := []byte("Hello, \u4e16\u754c! <tag> \"quoted\"\n")
+	dst := make([]byte, 0, 64)
+	for i := 0; i < b.N; i++ {
+		dst = AppendQuoteJSON(dst[:0], ba, true)
+		BenchSink += len(dst)
+	}
+}
+
+func BenchmarkUnquoteJSON(b *testing.B) {
+	for _, s := range []string{`"Hello, world"`, `"Hello,\tworld\n"`} {
+		ba := []byte(s)
+		b.Run(s, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				out, _ := UnquoteJSON(ba)
+				BenchSink += len(out)
+			}
+		})
+	}
+}
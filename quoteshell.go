@@ -0,0 +1,125 @@
+package baconv
+
+import (
+	"bytes"
+)
+
+// isShellSafe reports whether c can appear unquoted in a POSIX shell
+// word without being interpreted by the shell.
+func isShellSafe(c byte) bool {
+	switch {
+	case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		return true
+	}
+	switch c {
+	case '_', '@', '%', '+', '=', ':', ',', '.', '/', '-':
+		return true
+	}
+	return false
+}
+
+// AppendQuoteShell appends ba to dst as a single POSIX shell word and
+// returns the extended buffer. Words made only of ASCII letters, digits
+// and the characters _@%+=:,./- are appended unchanged. Anything else,
+// including the empty word, is enclosed in single quotes, inside which
+// the shell interprets nothing. Each single quote in ba is spliced in as
+//
+//	'\''
+//
+// which closes the quotes, adds an escaped quote and reopens them. The
+// bytes of ba are copied as they are, so newlines and invalid UTF-8
+// survive the round trip.
+func AppendQuoteShell(dst, ba []byte) []byte {
+	safe := len(ba) > 0
+	for _, c := range ba {
+		if !isShellSafe(c) {
+			safe = false
+			break
+		}
+	}
+	if safe {
+		return append(dst, ba...)
+	}
+	dst = append(dst, '\'')
+	for i := bytes.IndexByte(ba, '\''); i >= 0; i = bytes.IndexByte(ba, '\'') {
+		dst = append(dst, ba[:i]...)
+		dst = append(dst, `'\''`...)
+		ba = ba[i+1:]
+	}
+	dst = append(dst, ba...)
+	dst = append(dst, '\'')
+	return dst
+}
+
+// SplitShellWords splits ba into words the way a POSIX shell does after
+// quote removal. Words are separated by unquoted spaces, tabs and
+// newlines. A backslash outside quotes preserves the next character,
+// and a backslash-newline pair is removed. Single quotes preserve every
+// character up to the closing quote. Double quotes preserve every
+// character up to the closing quote except that a backslash escapes $,
+// `, ", \ and newline, and is otherwise kept.
+//
+// SplitShellWords performs no expansion and recognizes no operators or
+// comments: $, `, *, ~, |, ; and # are ordinary characters. It returns
+// ErrSyntax for an unterminated quote or a trailing backslash.
+func SplitShellWords(ba []byte) ([][]byte, error) {
+	var words [][]byte
+	// Quote removal only shrinks the input, so buf never grows and the
+	// words can share it.
+	buf := make([]byte, 0, len(ba))
+	start, inWord := 0, false
+	for i := 0; i < len(ba); i++ {
+		switch c := ba[i]; c {
+		case ' ', '\t', '\n':
+			if inWord {
+				words = append(words, buf[start:len(buf):len(buf)])
+				start, inWord = len(buf), false
+			}
+			continue
+		case '\\':
+			i++
+			if i == len(ba) {
+				return nil, ErrSyntax
+			}
+			if ba[i] == '\n' {
+				continue // line continuation
+			}
+			buf = append(buf, ba[i])
+		case '\'':
+			j := bytes.IndexByte(ba[i+1:], '\'')
+			if j < 0 {
+				return nil, ErrSyntax
+			}
+			buf = append(buf, ba[i+1:i+1+j]...)
+			i += 1 + j
+		case '"':
+			for i++; ; i++ {
+				if i == len(ba) {
+					return nil, ErrSyntax
+				}
+				c := ba[i]
+				if c == '"' {
+					break
+				}
+				if c == '\\' && i+1 < len(ba) {
+					switch ba[i+1] {
+					case '$', '`', '"', '\\':
+						i++
+						c = ba[i]
+					case '\n':
+						i++
+						continue
+					}
+				}
+				buf = append(buf, c)
+			}
+		default:
+			buf = append(buf, c)
+		}
+		inWord = true
+	}
+	if inWord {
+		words = append(words, buf[start:len(buf):len(buf)])
+	}
+	return words, nil
+}
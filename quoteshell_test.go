@@ -0,0 +1,156 @@
+package baconv
+
+import (
+	"bytes"
+	"os/exec"
+	"testing"
+)
+
+var quoteShellTests = []struct {
+	in  string
+	out string
+}{
+	{"", `''`},
+	{"abc", "abc"},
+	{"/usr/local/bin", "/usr/local/bin"},
+	{"--flag=a,b:c@d%e+f", "--flag=a,b:c@d%e+f"},
+	{"a b", `'a b'`},
+	{"it's", `'it'\''s'`},
+	{"'", `''\'''`},
+	{"''", `''\'''\'''`},
+	{"$HOME", `'$HOME'`},
+	{"`id`", "'`id`'"},
+	{`a\x41`, `'a\x41'`},
+	{`"q"`, `'"q"'`},
+	{"*.go", `'*.go'`},
+	{"~", `'~'`},
+	{"a;b|c&d", `'a;b|c&d'`},
+	{"#comment", `'#comment'`},
+	{"line\nbreak", "'line\nbreak'"},
+	{"\xe4\xb8\x96\xe7\x95\x8c", "'\xe4\xb8\x96\xe7\x95\x8c'"},
+	{"\xff\x00", "'\xff\x00'"},
+}
+
+func TestAppendQuoteShell(t *testing.T) {
+	for _, tt := range quoteShellTests {
+		out := AppendQuoteShell([]byte("x:"), []byte(tt.in))
+		if string(out) != "x:"+tt.out {
+			t.Errorf("AppendQuoteShell(%q) = %s, want %s", tt.in, out[2:], tt.out)
+		}
+	}
+}
+
+// Verify that a real shell reads back what AppendQuoteShell wrote.
+func TestAppendQuoteShellExec(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("no sh in PATH")
+	}
+	cmd := []byte(`printf '%s\0'`)
+	var want []byte
+	for _, tt := range quoteShellTests {
+		if bytes.IndexByte([]byte(tt.in), 0) >= 0 {
+			continue // cannot be passed as an argument
+		}
+		cmd = append(cmd, ' ')
+		cmd = AppendQuoteShell(cmd, []byte(tt.in))
+		want = append(want, tt.in...)
+		want = append(want, 0)
+	}
+	out, err := exec.Command(sh, "-c", string(cmd)).Output()
+	if err != nil {
+		t.Fatalf("sh -c %q: %v", cmd, err)
+	}
+	if !bytes.Equal(out, want) {
+		t.Errorf("sh -c %q printed %q, want %q", cmd, out, want)
+	}
+}
+
+var splitShellWordsTests = []struct {
+	in  string
+	out []string
+}{
+	{"", nil},
+	{" \t\n", nil},
+	{"a", []string{"a"}},
+	{"  a   b\tc\nd  ", []string{"a", "b", "c", "d"}},
+	{`''`, []string{""}},
+	{`a '' b`, []string{"a", "", "b"}},
+	{`""`, []string{""}},
+	{`'a b' "c d"`, []string{"a b", "c d"}},
+	{`'it'\''s'`, []string{"it's"}},
+	{`a'b'"c"d`, []string{"abcd"}},
+	{`'\n$x"'`, []string{`\n$x"`}},
+	{`"\$x \` + "`" + `y\` + "`" + ` \"z\" \\ \n"`, []string{"$x `y` \"z\" \\ \\n"}},
+	{`a\ b`, []string{"a b"}},
+	{`\'\"\\`, []string{`'"\`}},
+	{"a\\\nb", []string{"ab"}},
+	{"a \\\n b", []string{"a", "b"}},
+	{"\"a\\\nb\"", []string{"ab"}},
+	{"'a\\\nb'", []string{"a\\\nb"}},
+	{"$HOME *.go ~ a;b #c", []string{"$HOME", "*.go", "~", "a;b", "#c"}},
+	{"\xe4\xb8\x96 \xe7\x95\x8c", []string{"\xe4\xb8\x96", "\xe7\x95\x8c"}},
+}
+
+var missplitShellWords = []string{
+	`'`,
+	`"`,
+	`'abc`,
+	`"abc`,
+	`"abc\"`,
+	`a\`,
+	`'a'\`,
+}
+
+func TestSplitShellWords(t *testing.T) {
+	for _, tt := range splitShellWordsTests {
+		words, err := SplitShellWords([]byte(tt.in))
+		if err != nil || !equalWords(words, tt.out) {
+			t.Errorf("SplitShellWords(%#q) = %q, %v, want %q, nil", tt.in, words, err, tt.out)
+		}
+	}
+	for _, s := range missplitShellWords {
+		if words, err := SplitShellWords([]byte(s)); words != nil || err != ErrSyntax {
+			t.Errorf("SplitShellWords(%#q) = %q, %v, want nil, %v", s, words, err, ErrSyntax)
+		}
+	}
+}
+
+func TestSplitShellWordsRoundTrip(t *testing.T) {
+	var line []byte
+	var want []string
+	for _, tt := range quoteShellTests {
+		line = AppendQuoteShell(line, []byte(tt.in))
+		line = append(line, ' ')
+		want = append(want, tt.in)
+	}
+	words, err := SplitShellWords(line)
+	if err != nil || !equalWords(words, want) {
+		t.Errorf("SplitShellWords(%#q) = %q, %v, want %q, nil", line, words, err, want)
+	}
+}
+
+func equalWords(words [][]byte, want []string) bool {
+	if len(words) != len(want) {
+		return false
+	}
+	for i, w := range words {
+		if string(w) != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func BenchmarkAppendQuoteShell(b *testing.B) {
+	for _, s := range []string{"/usr/local/bin", "it's a test"} {
+		ba := []byte(s)
+		b.Run(s, func(b *testing.B) {
+			dst := make([]byte, 0, 64)
+			for i := 0; i < b.N; i++ {
+				dst = AppendQuoteShell(dst[:0], ba)
+				BenchSink += len(dst)
+			}
+		})
+	}
+}
@@ -0,0 +1,282 @@
+package baconv
+
+import (
+	"bytes"
+	"unicode/utf8"
+)
+
+// An SQLDialect selects the string literal syntax of an SQL database.
+type SQLDialect int
+
+const (
+	// SQLStandard literals are enclosed in single quotes, which are
+	// doubled inside; every other character stands for itself.
+	SQLStandard SQLDialect = iota
+
+	// SQLPostgres literals are standard literals, as PostgreSQL reads
+	// them with standard_conforming_strings on, unless they contain
+	// control characters. Those are written as escape strings, E'...',
+	// in which a backslash introduces a C-like escape.
+	SQLPostgres
+
+	// SQLMySQL literals are enclosed in single quotes and use backslash
+	// escapes, as MySQL reads them unless the NO_BACKSLASH_ESCAPES mode
+	// is set.
+	SQLMySQL
+)
+
+// AppendQuoteSQL appends an SQL string literal representing ba in the
+// given dialect to dst and returns the extended buffer. The literal is
+// for a text value, so ba must be valid UTF-8: as in Quote, a byte that
+// does not start a valid UTF-8 sequence is detected as a utf8.RuneError
+// of width 1, and for SQL the result is ErrInvalidUTF8 and dst unchanged
+// rather than an escape. PostgreSQL does not allow NUL characters in text
+// either, so in that dialect they are reported as ErrSyntax.
+// AppendQuoteSQL panics if dialect is not one of the SQLDialect constants.
+func AppendQuoteSQL(dst, ba []byte, dialect SQLDialect) ([]byte, error) {
+	switch dialect {
+	case SQLStandard, SQLPostgres, SQLMySQL:
+	default:
+		panic("bconv: invalid SQL dialect " + Itoba(int(dialect)))
+	}
+	escape := false
+	for i := 0; i < len(ba); {
+		c := ba[i]
+		if c >= utf8.RuneSelf {
+			r, width := utf8.DecodeRune(ba[i:])
+			if width == 1 && r == utf8.RuneError {
+				return dst, ErrInvalidUTF8
+			}
+			i += width
+			continue
+		}
+		switch dialect {
+		case SQLStandard:
+		case SQLPostgres:
+			if c == 0 {
+				return dst, ErrSyntax
+			}
+			escape = escape || c < ' ' || c == 0x7F
+		case SQLMySQL:
+			escape = escape || c == 0 || c == '\b' || c == '\n' || c == '\r' || c == '\t' || c == 0x1A || c == '\\'
+		}
+		i++
+	}
+
+	if !escape {
+		// Standard syntax: only the quote needs doubling.
+		dst = append(dst, '\'')
+		for i := bytes.IndexByte(ba, '\''); i >= 0; i = bytes.IndexByte(ba, '\'') {
+			dst = append(dst, ba[:i+1]...)
+			dst = append(dst, '\'')
+			ba = ba[i+1:]
+		}
+		dst = append(dst, ba...)
+		return append(dst, '\''), nil
+	}
+
+	if dialect == SQLPostgres {
+		dst = append(dst, 'E')
+	}
+	dst = append(dst, '\'')
+	for _, c := range ba {
+		dst = appendEscapedByteSQL(dst, c, dialect)
+	}
+	return append(dst, '\''), nil
+}
+
+// appendEscapedByteSQL appends c to buf as it appears in a backslash
+// escaped literal of the given dialect.
+func appendEscapedByteSQL(buf []byte, c byte, dialect SQLDialect) []byte {
+	switch c {
+	case '\'', '\\':
+		return append(buf, '\\', c)
+	case '\b':
+		return append(buf, `\b`...)
+	case '\n':
+		return append(buf, `\n`...)
+	case '\r':
+		return append(buf, `\r`...)
+	case '\t':
+		return append(buf, `\t`...)
+	}
+	if dialect == SQLMySQL {
+		switch c {
+		case 0:
+			return append(buf, `\0`...)
+		case 0x1A:
+			return append(buf, `\Z`...)
+		}
+		return append(buf, c)
+	}
+	switch {
+	case c == '\f':
+		return append(buf, `\f`...)
+	case c < ' ' || c == 0x7F:
+		return append(buf, '\\', 'x', lowerhex[c>>4], lowerhex[c&0xF])
+	}
+	return append(buf, c)
+}
+
+// UnquoteSQL interprets ba as an SQL string literal in the given dialect
+// and returns the text it represents. For SQLPostgres, both standard
+// literals and escape strings, E'...', are accepted; escape strings may
+// use the escapes \b, \f, \n, \r, \t, octal \o to \ooo, hexadecimal \xh
+// and \xhh, \uXXXX and \UXXXXXXXX, and a backslash before any other
+// character stands for that character. For SQLMySQL, the escapes are
+// \0, \b, \n, \r, \t and \Z, while \% and \_ keep their backslash as in
+// MySQL, and a backslash before any other character stands for that
+// character. In every dialect a doubled quote stands for one quote.
+//
+// The result must be valid UTF-8, or UnquoteSQL returns ErrInvalidUTF8;
+// other malformed literals yield ErrSyntax. If ba contains no escapes,
+// the result is a subslice of ba and no memory is allocated.
+// UnquoteSQL panics if dialect is not one of the SQLDialect constants.
+func UnquoteSQL(ba []byte, dialect SQLDialect) ([]byte, error) {
+	backslash := false
+	switch dialect {
+	case SQLStandard:
+	case SQLPostgres:
+		if len(ba) > 0 && (ba[0] == 'E' || ba[0] == 'e') {
+			ba = ba[1:]
+			backslash = true
+		}
+	case SQLMySQL:
+		backslash = true
+	default:
+		panic("bconv: invalid SQL dialect " + Itoba(int(dialect)))
+	}
+	n := len(ba)
+	if n < 2 || ba[0] != '\'' || ba[n-1] != '\'' {
+		return nil, ErrSyntax
+	}
+	ba = ba[1 : n-1]
+
+	// Is it trivial? Avoid allocation.
+	i := 0
+	for i < len(ba) && ba[i] != '\'' && !(backslash && ba[i] == '\\') {
+		i++
+	}
+	if i == len(ba) {
+		if !utf8.Valid(ba) {
+			return nil, ErrInvalidUTF8
+		}
+		if dialect == SQLPostgres && bytes.IndexByte(ba, 0) >= 0 {
+			return nil, ErrSyntax
+		}
+		return ba, nil
+	}
+
+	buf := make([]byte, i, len(ba))
+	copy(buf, ba)
+	for ba = ba[i:]; len(ba) > 0; {
+		c := ba[0]
+		switch {
+		case c == '\'':
+			if len(ba) < 2 || ba[1] != '\'' {
+				return nil, ErrSyntax
+			}
+			buf = append(buf, '\'')
+			ba = ba[2:]
+		case c == '\\' && backslash:
+			var err error
+			if buf, ba, err = unescapeSQL(buf, ba[1:], dialect); err != nil {
+				return nil, err
+			}
+		default:
+			buf = append(buf, c)
+			ba = ba[1:]
+		}
+	}
+	if !utf8.Valid(buf) {
+		return nil, ErrInvalidUTF8
+	}
+	if dialect == SQLPostgres && bytes.IndexByte(buf, 0) >= 0 {
+		return nil, ErrSyntax
+	}
+	return buf, nil
+}
+
+// unescapeSQL decodes the backslash escape whose body starts ba, appends
+// its value to buf and returns the rest of ba.
+func unescapeSQL(buf, ba []byte, dialect SQLDialect) ([]byte, []byte, error) {
+	if len(ba) == 0 {
+		return nil, nil, ErrSyntax
+	}
+	c := ba[0]
+	ba = ba[1:]
+	switch c {
+	case 'b':
+		return append(buf, '\b'), ba, nil
+	case 'n':
+		return append(buf, '\n'), ba, nil
+	case 'r':
+		return append(buf, '\r'), ba, nil
+	case 't':
+		return append(buf, '\t'), ba, nil
+	}
+	if dialect == SQLMySQL {
+		switch c {
+		case '0':
+			return append(buf, 0), ba, nil
+		case 'Z':
+			return append(buf, 0x1A), ba, nil
+		case '%', '_':
+			return append(buf, '\\', c), ba, nil
+		}
+		return append(buf, c), ba, nil
+	}
+
+	switch c {
+	case 'f':
+		return append(buf, '\f'), ba, nil
+	case '0', '1', '2', '3', '4', '5', '6', '7':
+		v := int(c - '0')
+		for j := 0; j < 2 && len(ba) > 0 && '0' <= ba[0] && ba[0] <= '7'; j++ {
+			v = v<<3 | int(ba[0]-'0')
+			ba = ba[1:]
+		}
+		if v > 255 {
+			return nil, nil, ErrSyntax
+		}
+		return append(buf, byte(v)), ba, nil
+	case 'x':
+		var v byte
+		j := 0
+		for ; j < 2 && j < len(ba); j++ {
+			x, ok := unhex(ba[j])
+			if !ok {
+				break
+			}
+			v = v<<4 | byte(x)
+		}
+		if j == 0 {
+			// PostgreSQL reads \x without digits as x.
+			return append(buf, 'x'), ba, nil
+		}
+		return append(buf, v), ba[j:], nil
+	case 'u', 'U':
+		n := 4
+		if c == 'U' {
+			n = 8
+		}
+		if len(ba) < n {
+			return nil, nil, ErrSyntax
+		}
+		var v rune
+		for j := 0; j < n; j++ {
+			x, ok := unhex(ba[j])
+			if !ok {
+				return nil, nil, ErrSyntax
+			}
+			v = v<<4 | x
+		}
+		if !utf8.ValidRune(v) {
+			return nil, nil, ErrSyntax
+		}
+		var runeTmp [utf8.UTFMax]byte
+		w := utf8.EncodeRune(runeTmp[:], v)
+		return append(buf, runeTmp[:w]...), ba[n:], nil
+	}
+	return append(buf, c), ba, nil
+}
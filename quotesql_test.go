@@ -0,0 +1,165 @@
+package baconv
+
+import (
+	"testing"
+)
+
+var quoteSQLTests = []struct {
+	in       string
+	standard string
+	postgres string
+	mysql    string
+}{
+	{"", `''`, `''`, `''`},
+	{"abc", `'abc'`, `'abc'`, `'abc'`},
+	{"O'Brien", `'O''Brien'`, `'O''Brien'`, `'O''Brien'`},
+	{"''", `''''''`, `''''''`, `''''''`},
+	{`"q"`, `'"q"'`, `'"q"'`, `'"q"'`},
+	{`C:\dir`, `'C:\dir'`, `'C:\dir'`, `'C:\\dir'`},
+	{`it's C:\`, `'it''s C:\'`, `'it''s C:\'`, `'it\'s C:\\'`},
+	{"a\nb", "'a\nb'", `E'a\nb'`, `'a\nb'`},
+	{"O'\tB\\", "'O''\tB\\'", `E'O\'\tB\\'`, `'O\'\tB\\'`},
+	{"\b\f\r\x1a\x7f", "'\b\f\r\x1a\x7f'", `E'\b\f\r\x1a\x7f'`, "'\\b\f\\r\\Z\x7f'"},
+	{"%_", `'%_'`, `'%_'`, `'%_'`},
+	{"\xe4\xb8\x96\xe7\x95\x8c", "'\xe4\xb8\x96\xe7\x95\x8c'", "'\xe4\xb8\x96\xe7\x95\x8c'", "'\xe4\xb8\x96\xe7\x95\x8c'"},
+	{"\xef\xbf\xbd", "'\xef\xbf\xbd'", "'\xef\xbf\xbd'", "'\xef\xbf\xbd'"}, // U+FFFD itself is valid
+}
+
+var sqlDialects = []SQLDialect{SQLStandard, SQLPostgres, SQLMySQL}
+
+func TestAppendQuoteSQL(t *testing.T) {
+	for _, tt := range quoteSQLTests {
+		for i, want := range []string{tt.standard, tt.postgres, tt.mysql} {
+			out, err := AppendQuoteSQL([]byte("x:"), []byte(tt.in), sqlDialects[i])
+			if err != nil || string(out) != "x:"+want {
+				t.Errorf("AppendQuoteSQL(%q, %d) = %s, %v, want %s, nil", tt.in, sqlDialects[i], out[2:], err, want)
+			}
+		}
+	}
+}
+
+func TestAppendQuoteSQLErrors(t *testing.T) {
+	tests := []struct {
+		in      string
+		dialect SQLDialect
+		err     error
+	}{
+		{"\xff", SQLStandard, ErrInvalidUTF8},
+		{"ab\xe4\xb8", SQLPostgres, ErrInvalidUTF8},
+		{"\xc0\x80", SQLMySQL, ErrInvalidUTF8},
+		{"\xed\xa0\x80", SQLStandard, ErrInvalidUTF8}, // surrogate
+		{"a\x00b", SQLPostgres, ErrSyntax},
+	}
+	for _, tt := range tests {
+		out, err := AppendQuoteSQL([]byte("x:"), []byte(tt.in), tt.dialect)
+		if err != tt.err || string(out) != "x:" {
+			t.Errorf("AppendQuoteSQL(%q, %d) = %q, %v, want %q, %v", tt.in, tt.dialect, out, err, "x:", tt.err)
+		}
+	}
+	if out, err := AppendQuoteSQL(nil, []byte("a\x00b"), SQLMySQL); err != nil || string(out) != `'a\0b'` {
+		t.Errorf("AppendQuoteSQL(%q, SQLMySQL) = %s, %v, want %s, nil", "a\x00b", out, err, `'a\0b'`)
+	}
+}
+
+var unquoteSQLTests = []struct {
+	in      string
+	dialect SQLDialect
+	out     string
+}{
+	{`'it''s'`, SQLStandard, "it's"},
+	{`'a\nb'`, SQLStandard, `a\nb`},
+	{`'a\nb'`, SQLPostgres, `a\nb`},
+	{`e'a\nb'`, SQLPostgres, "a\nb"},
+	{`E'\'\\\b\f\n\r\t\q'`, SQLPostgres, "'\\\b\f\n\r\tq"},
+	{`E'\101\1012\7'`, SQLPostgres, "AA2\a"},
+	{`E'\x41\x412\xg'`, SQLPostgres, "AA2xg"},
+	{`E'\u00e9\U0001F600'`, SQLPostgres, "\xc3\xa9\xf0\x9f\x98\x80"},
+	{`E'\xc3\xa9'`, SQLPostgres, "\xc3\xa9"},
+	{`'\0\'\"\b\n\r\t\Z\\\%\_\q'`, SQLMySQL, "\x00'\"\b\n\r\t\x1a\\\\%\\_q"},
+	{`'a''b\'c'`, SQLMySQL, "a'b'c"},
+}
+
+var misquotedSQL = []struct {
+	in      string
+	dialect SQLDialect
+	err     error
+}{
+	{``, SQLStandard, ErrSyntax},
+	{`'`, SQLStandard, ErrSyntax},
+	{`'abc`, SQLStandard, ErrSyntax},
+	{`"abc"`, SQLStandard, ErrSyntax},
+	{`'a'b'`, SQLStandard, ErrSyntax},
+	{`'it\'s'`, SQLStandard, ErrSyntax},
+	{`E'a'`, SQLStandard, ErrSyntax},
+	{`E'a'`, SQLMySQL, ErrSyntax},
+	{`E'\'`, SQLPostgres, ErrSyntax},
+	{`E'\777'`, SQLPostgres, ErrSyntax},
+	{`E'\u12'`, SQLPostgres, ErrSyntax},
+	{`E'\ud83d'`, SQLPostgres, ErrSyntax},
+	{`E'\U00110000'`, SQLPostgres, ErrSyntax},
+	{`E'a\0b'`, SQLPostgres, ErrSyntax},
+	{"'a\x00b'", SQLPostgres, ErrSyntax},
+	{`'\'`, SQLMySQL, ErrSyntax},
+	{"'\xff'", SQLStandard, ErrInvalidUTF8},
+	{`E'\xff'`, SQLPostgres, ErrInvalidUTF8},
+	{`'it''s` + "\xe4\xb8'", SQLMySQL, ErrInvalidUTF8},
+}
+
+func TestUnquoteSQL(t *testing.T) {
+	for _, tt := range unquoteSQLTests {
+		out, err := UnquoteSQL([]byte(tt.in), tt.dialect)
+		if err != nil || string(out) != tt.out {
+			t.Errorf("UnquoteSQL(%#q, %d) = %q, %v, want %q, nil", tt.in, tt.dialect, out, err, tt.out)
+		}
+	}
+	for _, tt := range quoteSQLTests {
+		for i, q := range []string{tt.standard, tt.postgres, tt.mysql} {
+			out, err := UnquoteSQL([]byte(q), sqlDialects[i])
+			if err != nil || string(out) != tt.in {
+				t.Errorf("UnquoteSQL(%#q, %d) = %q, %v, want %q, nil", q, sqlDialects[i], out, err, tt.in)
+			}
+		}
+	}
+	for _, tt := range misquotedSQL {
+		if out, err := UnquoteSQL([]byte(tt.in), tt.dialect); out != nil || err != tt.err {
+			t.Errorf("UnquoteSQL(%#q, %d) = %q, %v, want nil, %v", tt.in, tt.dialect, out, err, tt.err)
+		}
+	}
+}
+
+func TestSQLDialectPanic(t *testing.T) {
+	for _, fn := range []func(){
+		func() { AppendQuoteSQL(nil, []byte("a"), SQLDialect(3)) },
+		func() { AppendQuoteSQL(nil, nil, SQLDialect(7)) },
+		func() { AppendQuoteSQL(nil, []byte("世界"), SQLDialect(-1)) },
+		func() { UnquoteSQL([]byte("'a'"), SQLDialect(-1)) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("invalid SQL dialect did not panic")
+				}
+			}()
+			fn()
+		}()
+	}
+}
+
+func FuzzQuoteSQL(f *testing.F) {
+	for _, tt := range quoteSQLTests {
+		for _, d := range sqlDialects {
+			f.Add([]byte(tt.in), int(d))
+		}
+	}
+	f.Fuzz(func(t *testing.T, ba []byte, d int) {
+		dialect := sqlDialects[uint(d)%uint(len(sqlDialects))]
+		quoted, err := AppendQuoteSQL(nil, ba, dialect)
+		if err != nil {
+			return
+		}
+		out, err := UnquoteSQL(quoted, dialect)
+		if err != nil || string(out) != string(ba) {
+			t.Fatalf("UnquoteSQL(%#q, %d) = %q, %v, want %q, nil", quoted, dialect, out, err, ba)
+		}
+	})
+}
@@ -0,0 +1,216 @@
+package baconv
+
+import (
+	"sync"
+	"time"
+)
+
+// fixedDigits returns the value of ba, which must be non-empty
+// and consist of decimal digits only.
+func fixedDigits(ba []byte) (n int, ok bool) {
+	if len(ba) == 0 {
+		return 0, false
+	}
+	for _, c := range ba {
+		c -= '0'
+		if c > 9 {
+			return 0, false
+		}
+		n = n*10 + int(c)
+	}
+	return n, true
+}
+
+// daysIn returns the number of days in month m of year.
+func daysIn(m time.Month, year int) int {
+	if m == time.February {
+		if year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+			return 29
+		}
+		return 28
+	}
+	// Months alternate 31 and 30 days, restarting in August.
+	return 30 + int((m+m/8)&1)
+}
+
+var (
+	fixedZonesMu sync.RWMutex
+	fixedZones   = make(map[int]*time.Location)
+)
+
+// fixedZone returns an unnamed location with the given offset in seconds
+// east of UTC. Locations are cached, so repeated offsets do not allocate.
+func fixedZone(offset int) *time.Location {
+	if offset == 0 {
+		return time.UTC
+	}
+	fixedZonesMu.RLock()
+	loc := fixedZones[offset]
+	fixedZonesMu.RUnlock()
+	if loc != nil {
+		return loc
+	}
+	fixedZonesMu.Lock()
+	defer fixedZonesMu.Unlock()
+	if loc = fixedZones[offset]; loc == nil {
+		loc = time.FixedZone("", offset)
+		fixedZones[offset] = loc
+	}
+	return loc
+}
+
+// ParseRFC3339 parses an RFC 3339 timestamp such as
+// "2006-01-02T15:04:05.999999999Z07:00". The fractional second is
+// optional and may have any number of digits; digits beyond
+// nanoseconds are truncated. The letters T and Z may be lower case.
+//
+// A Z or zero offset yields a time in time.UTC; any other offset yields
+// a time in an unnamed fixed zone. Neither case allocates once the
+// zone for a given offset has been seen.
+//
+// The errors that ParseRFC3339 returns have concrete type *NumError
+// and include err.Num = s. If s is not a valid timestamp,
+// err.Err = ErrSyntax; if a field is out of range (such as month 13 or
+// February 30), err.Err = ErrRange.
+func ParseRFC3339(ba []byte) (time.Time, error) {
+	const fnParseRFC3339 = "ParseRFC3339"
+
+	// 2006-01-02T15:04:05Z
+	if len(ba) < len("2006-01-02T15:04:05Z") ||
+		ba[4] != '-' || ba[7] != '-' || ba[10] != 'T' && ba[10] != 't' ||
+		ba[13] != ':' || ba[16] != ':' {
+		return time.Time{}, syntaxError(fnParseRFC3339, string(ba))
+	}
+	year, ok1 := fixedDigits(ba[0:4])
+	month, ok2 := fixedDigits(ba[5:7])
+	day, ok3 := fixedDigits(ba[8:10])
+	hour, ok4 := fixedDigits(ba[11:13])
+	minute, ok5 := fixedDigits(ba[14:16])
+	sec, ok6 := fixedDigits(ba[17:19])
+	if !(ok1 && ok2 && ok3 && ok4 && ok5 && ok6) {
+		return time.Time{}, syntaxError(fnParseRFC3339, string(ba))
+	}
+	rest := ba[19:]
+
+	// optional fractional second
+	nsec := 0
+	if rest[0] == '.' {
+		i := 1
+		for ; i < len(rest) && '0' <= rest[i] && rest[i] <= '9'; i++ {
+			if i <= 9 {
+				nsec = nsec*10 + int(rest[i]-'0')
+			}
+		}
+		if i == 1 {
+			return time.Time{}, syntaxError(fnParseRFC3339, string(ba))
+		}
+		for j := i; j <= 9; j++ {
+			nsec *= 10
+		}
+		rest = rest[i:]
+	}
+
+	// zone
+	offset := 0
+	switch {
+	case len(rest) == 1 && (rest[0] == 'Z' || rest[0] == 'z'):
+	case len(rest) == 6 && (rest[0] == '+' || rest[0] == '-') && rest[3] == ':':
+		hh, ok1 := fixedDigits(rest[1:3])
+		mm, ok2 := fixedDigits(rest[4:6])
+		if !ok1 || !ok2 {
+			return time.Time{}, syntaxError(fnParseRFC3339, string(ba))
+		}
+		if hh > 23 || mm > 59 {
+			return time.Time{}, rangeError(fnParseRFC3339, string(ba))
+		}
+		offset = (hh*60 + mm) * 60
+		if rest[0] == '-' {
+			offset = -offset
+		}
+	default:
+		return time.Time{}, syntaxError(fnParseRFC3339, string(ba))
+	}
+
+	if month < 1 || month > 12 || day < 1 || day > daysIn(time.Month(month), year) ||
+		hour > 23 || minute > 59 || sec > 59 {
+		return time.Time{}, rangeError(fnParseRFC3339, string(ba))
+	}
+	return time.Date(year, time.Month(month), day, hour, minute, sec, nsec, fixedZone(offset)), nil
+}
+
+// AppendRFC3339 appends the RFC 3339 form of t, as generated by
+// t.Format(time.RFC3339Nano) but with a fixed number of fractional
+// digits, to dst and returns the extended buffer. fracDigits must be
+// between 0 and 9; a negative value formats the shortest fraction that
+// represents t exactly, as time.RFC3339Nano does. Years outside
+// 0000 to 9999 are formatted by the time package.
+func AppendRFC3339(dst []byte, t time.Time, fracDigits int) []byte {
+	if fracDigits > 9 {
+		panic("bconv: illegal AppendRFC3339 fracDigits")
+	}
+	year, month, day := t.Date()
+	if year < 0 || year > 9999 {
+		layout := "2006-01-02T15:04:05.999999999Z07:00"
+		if fracDigits >= 0 {
+			layout = "2006-01-02T15:04:05" + ".000000000"[:fracDigits+1] + "Z07:00"
+			if fracDigits == 0 {
+				layout = time.RFC3339
+			}
+		}
+		return t.AppendFormat(dst, layout)
+	}
+	hour, minute, sec := t.Clock()
+
+	dst = append(dst, smallsString[year/100*2:year/100*2+2]...)
+	dst = append(dst, smallsString[year%100*2:year%100*2+2]...)
+	dst = append(dst, '-')
+	dst = append(dst, smallsString[month*2:month*2+2]...)
+	dst = append(dst, '-')
+	dst = append(dst, smallsString[day*2:day*2+2]...)
+	dst = append(dst, 'T')
+	dst = append(dst, smallsString[hour*2:hour*2+2]...)
+	dst = append(dst, ':')
+	dst = append(dst, smallsString[minute*2:minute*2+2]...)
+	dst = append(dst, ':')
+	dst = append(dst, smallsString[sec*2:sec*2+2]...)
+
+	nsec := t.Nanosecond()
+	n := fracDigits
+	if n < 0 {
+		// shortest exact fraction
+		n = 9
+		for n > 0 && nsec%10 == 0 {
+			nsec /= 10
+			n--
+		}
+	} else {
+		for i := n; i < 9; i++ {
+			nsec /= 10
+		}
+	}
+	if n > 0 {
+		var a [10]byte
+		a[0] = '.'
+		for i := n; i > 0; i-- {
+			a[i] = byte(nsec%10) + '0'
+			nsec /= 10
+		}
+		dst = append(dst, a[:n+1]...)
+	}
+
+	_, offset := t.Zone()
+	if offset == 0 {
+		return append(dst, 'Z')
+	}
+	zone := offset / 60 // convert to minutes
+	if zone < 0 {
+		dst = append(dst, '-')
+		zone = -zone
+	} else {
+		dst = append(dst, '+')
+	}
+	dst = append(dst, smallsString[zone/60*2:zone/60*2+2]...)
+	dst = append(dst, ':')
+	dst = append(dst, smallsString[zone%60*2:zone%60*2+2]...)
+	return dst
+}
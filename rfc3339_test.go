@@ -0,0 +1,177 @@
+package baconv
+
+import (
+	"testing"
+	"time"
+)
+
+type parseRFC3339Test struct {
+	in  string
+	err error
+}
+
+var parseRFC3339Tests = []parseRFC3339Test{
+	{"2006-01-02T15:04:05Z", nil},
+	{"2006-01-02t15:04:05z", nil},
+	{"2006-01-02T15:04:05+07:00", nil},
+	{"2006-01-02T15:04:05-07:30", nil},
+	{"2006-01-02T15:04:05+00:00", nil},
+	{"2006-01-02T15:04:05.1Z", nil},
+	{"2006-01-02T15:04:05.123456789Z", nil},
+	{"2006-01-02T15:04:05.1234567891234Z", nil},
+	{"2006-01-02T15:04:05.000100+05:45", nil},
+	{"0000-01-01T00:00:00Z", nil},
+	{"9999-12-31T23:59:59.999999999-23:59", nil},
+	{"2000-02-29T00:00:00Z", nil},
+	{"2024-02-29T00:00:00Z", nil},
+
+	{"", ErrSyntax},
+	{"2006-01-02", ErrSyntax},
+	{"2006-01-02T15:04:05", ErrSyntax},
+	{"2006-01-02 15:04:05Z", ErrSyntax},
+	{"2006/01/02T15:04:05Z", ErrSyntax},
+	{"2006-01-02T15:04:05.Z", ErrSyntax},
+	{"2006-01-02T15:04:05,5Z", ErrSyntax},
+	{"2006-01-02T15:04:05+0700", ErrSyntax},
+	{"2006-01-02T15:04:05+07:00x", ErrSyntax},
+	{"2006-01-02T15:04:05ZZ", ErrSyntax},
+	{"2006-0a-02T15:04:05Z", ErrSyntax},
+	{"2006-01-02T15:04:05+0a:00", ErrSyntax},
+	{"206-01-02T15:04:05Z", ErrSyntax},
+	{"2006-00-02T15:04:05Z", ErrRange},
+	{"2006-13-02T15:04:05Z", ErrRange},
+	{"2006-01-00T15:04:05Z", ErrRange},
+	{"2006-01-32T15:04:05Z", ErrRange},
+	{"2006-02-29T15:04:05Z", ErrRange},
+	{"1900-02-29T15:04:05Z", ErrRange},
+	{"2006-04-31T15:04:05Z", ErrRange},
+	{"2006-01-02T24:04:05Z", ErrRange},
+	{"2006-01-02T15:60:05Z", ErrRange},
+	{"2006-01-02T15:04:60Z", ErrRange},
+	{"2006-01-02T15:04:05+24:00", ErrRange},
+	{"2006-01-02T15:04:05+00:60", ErrRange},
+}
+
+func TestParseRFC3339(t *testing.T) {
+	for _, test := range parseRFC3339Tests {
+		got, err := ParseRFC3339([]byte(test.in))
+		if test.err != nil {
+			if err == nil {
+				t.Errorf("ParseRFC3339(%q) = %v, want error %v", test.in, got, test.err)
+			} else if err.(*NumError).Err != test.err {
+				t.Errorf("ParseRFC3339(%q): error %v, want %v", test.in, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseRFC3339(%q): unexpected error %v", test.in, err)
+			continue
+		}
+		want, werr := time.Parse(time.RFC3339Nano, test.in)
+		if werr != nil {
+			// time.Parse is stricter about the letter case of T and Z.
+			continue
+		}
+		if !got.Equal(want) {
+			t.Errorf("ParseRFC3339(%q) = %v, want %v", test.in, got, want)
+		}
+		if _, off := got.Zone(); off != func() int { _, o := want.Zone(); return o }() {
+			t.Errorf("ParseRFC3339(%q) has offset %d, want that of %v", test.in, off, want)
+		}
+	}
+}
+
+func TestParseRFC3339Location(t *testing.T) {
+	got, _ := ParseRFC3339([]byte("2006-01-02T15:04:05Z"))
+	if got.Location() != time.UTC {
+		t.Errorf("ParseRFC3339 with Z: location %v, want UTC", got.Location())
+	}
+	got, _ = ParseRFC3339([]byte("2006-01-02T15:04:05-00:00"))
+	if got.Location() != time.UTC {
+		t.Errorf("ParseRFC3339 with -00:00: location %v, want UTC", got.Location())
+	}
+	a, _ := ParseRFC3339([]byte("2006-01-02T15:04:05+05:45"))
+	b, _ := ParseRFC3339([]byte("2007-01-02T15:04:05+05:45"))
+	if a.Location() != b.Location() {
+		t.Errorf("ParseRFC3339 did not reuse the +05:45 location")
+	}
+}
+
+var appendRFC3339Times = []time.Time{
+	time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC),
+	time.Date(2006, 1, 2, 15, 4, 5, 123456789, time.UTC),
+	time.Date(2006, 1, 2, 15, 4, 5, 120000000, time.FixedZone("", -7*3600)),
+	time.Date(1999, 12, 31, 23, 59, 59, 1000, time.FixedZone("X", 5*3600+45*60)),
+	time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC),
+	time.Date(-1, 1, 1, 0, 0, 0, 500, time.UTC),
+	time.Date(10000, 1, 1, 0, 0, 0, 0, time.FixedZone("", 3600)),
+}
+
+func TestAppendRFC3339(t *testing.T) {
+	layouts := []string{
+		"2006-01-02T15:04:05Z07:00",
+		"2006-01-02T15:04:05.0Z07:00",
+		"2006-01-02T15:04:05.000Z07:00",
+		"2006-01-02T15:04:05.000000Z07:00",
+		"2006-01-02T15:04:05.000000000Z07:00",
+	}
+	digits := []int{0, 1, 3, 6, 9}
+	for _, tm := range appendRFC3339Times {
+		for i, layout := range layouts {
+			got := string(AppendRFC3339([]byte("t="), tm, digits[i]))
+			if want := "t=" + tm.Format(layout); got != want {
+				t.Errorf("AppendRFC3339(%v, %d) = %q, want %q", tm, digits[i], got, want)
+			}
+		}
+		got := string(AppendRFC3339(nil, tm, -1))
+		if want := tm.Format(time.RFC3339Nano); got != want {
+			t.Errorf("AppendRFC3339(%v, -1) = %q, want %q", tm, got, want)
+		}
+		if tm.Year() < 0 || tm.Year() > 9999 {
+			continue
+		}
+		back, err := ParseRFC3339([]byte(got))
+		if err != nil || !back.Equal(tm) {
+			t.Errorf("ParseRFC3339(%q) = %v, %v, want %v", got, back, err, tm)
+		}
+	}
+}
+
+func FuzzParseRFC3339(f *testing.F) {
+	for _, test := range parseRFC3339Tests {
+		f.Add(test.in)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		got, err := ParseRFC3339([]byte(s))
+		if err != nil {
+			return
+		}
+		want, werr := time.Parse(time.RFC3339Nano, s)
+		if werr != nil {
+			if s[10] == 't' || s[len(s)-1] == 'z' {
+				return
+			}
+			t.Fatalf("ParseRFC3339(%q) = %v, but time.Parse failed: %v", s, got, werr)
+		}
+		if !got.Equal(want) {
+			t.Fatalf("ParseRFC3339(%q) = %v, want %v", s, got, want)
+		}
+	})
+}
+
+func BenchmarkParseRFC3339(b *testing.B) {
+	ba := []byte("2006-01-02T15:04:05.999999999+07:00")
+	for i := 0; i < b.N; i++ {
+		t, _ := ParseRFC3339(ba)
+		BenchSink += t.Nanosecond()
+	}
+}
+
+func BenchmarkAppendRFC3339(b *testing.B) {
+	dst := make([]byte, 0, 64)
+	t := time.Date(2006, 1, 2, 15, 4, 5, 999999999, time.FixedZone("", 7*3600))
+	for i := 0; i < b.N; i++ {
+		dst = AppendRFC3339(dst[:0], t, 9)
+		BenchSink += len(dst)
+	}
+}
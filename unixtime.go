@@ -0,0 +1,123 @@
+package baconv
+
+import (
+	"errors"
+	"time"
+)
+
+func unitError(fn, str string, unit time.Duration) *NumError {
+	return &NumError{fn, str, errors.New("invalid unit " + unit.String())}
+}
+
+// unixScale returns the power of ten that converts unit to nanoseconds.
+func unixScale(unit time.Duration) (scale int, ok bool) {
+	switch unit {
+	case time.Second:
+		return 9, true
+	case time.Millisecond:
+		return 6, true
+	case time.Microsecond:
+		return 3, true
+	case time.Nanosecond:
+		return 0, true
+	}
+	return 0, false
+}
+
+// detectUnixUnit guesses the unit of a Unix timestamp from the number
+// of digits in its integer part: up to 11 digits are seconds, up to 14
+// milliseconds, up to 17 microseconds and anything longer nanoseconds.
+// Around the present day these are 10, 13, 16 and 19 digits.
+func detectUnixUnit(intDigits int) time.Duration {
+	switch {
+	case intDigits <= 11:
+		return time.Second
+	case intDigits <= 14:
+		return time.Millisecond
+	case intDigits <= 17:
+		return time.Microsecond
+	}
+	return time.Nanosecond
+}
+
+// ParseUnixTime interprets ba as a decimal count of unit since
+// January 1, 1970 UTC and returns the corresponding local time,
+// as time.Unix does. The unit must be time.Second, time.Millisecond,
+// time.Microsecond or time.Nanosecond; if it is 0, the unit is
+// detected from the number of digits before the decimal point, so that
+// 1700000000, 1700000000123 and 1700000000123456789 are all accepted.
+//
+// The number may be signed and may have a fraction and an exponent,
+// such as 1700000000.123456 or 1.7e9. It is converted exactly, without
+// floating-point rounding; digits below one nanosecond are truncated.
+// At most 19 significant digits down to the nanosecond are supported.
+//
+// The errors that ParseUnixTime returns have concrete type *NumError
+// and include err.Num = s. If s is syntactically invalid,
+// err.Err = ErrSyntax; if the time in seconds does not fit an int64,
+// or it has too many significant digits, err.Err = ErrRange.
+func ParseUnixTime(ba []byte, unit time.Duration) (time.Time, error) {
+	const fnParseUnixTime = "ParseUnixTime"
+
+	mantissa, exp, neg, trunc, ok := readFloat(ba, &lenientFloat)
+	if !ok {
+		return time.Time{}, syntaxError(fnParseUnixTime, string(ba))
+	}
+	if unit == 0 {
+		intDigits := 0
+		if mantissa != 0 {
+			intDigits = decimalLen(mantissa) + exp
+		}
+		unit = detectUnixUnit(intDigits)
+	}
+	scale, ok := unixScale(unit)
+	if !ok {
+		return time.Time{}, unitError(fnParseUnixTime, string(ba), unit)
+	}
+
+	// The value in nanoseconds is mantissa * 10^e.
+	e := exp + scale
+	if trunc && e > 0 {
+		// Non-zero digits at or above nanoseconds were dropped.
+		return time.Time{}, rangeError(fnParseUnixTime, string(ba))
+	}
+	var sec, nsec uint64
+	switch {
+	case mantissa == 0:
+	case e >= 9:
+		if e-9 >= len(uint64pow10) || mantissa > (1<<63-1)/uint64pow10[e-9] {
+			return time.Time{}, rangeError(fnParseUnixTime, string(ba))
+		}
+		sec = mantissa * uint64pow10[e-9]
+	case e >= 0:
+		p := uint64pow10[9-e]
+		sec = mantissa / p
+		nsec = (mantissa - sec*p) * uint64pow10[e]
+	case -e < len(uint64pow10):
+		ns := mantissa / uint64pow10[-e]
+		sec, nsec = ns/1e9, ns%1e9
+	}
+	if sec > 1<<63-1 {
+		return time.Time{}, rangeError(fnParseUnixTime, string(ba))
+	}
+	if neg {
+		return time.Unix(-int64(sec), -int64(nsec)), nil
+	}
+	return time.Unix(int64(sec), int64(nsec)), nil
+}
+
+// AppendUnixTime appends the number of unit elapsed since January 1,
+// 1970 UTC until t, in decimal, to dst and returns the extended buffer.
+// Partial units are discarded, rounding towards the past, as t.Unix does.
+// The unit must be time.Second, time.Millisecond, time.Microsecond or
+// time.Nanosecond. The result is undefined if the count does not fit an
+// int64, as with t.UnixNano.
+func AppendUnixTime(dst []byte, t time.Time, unit time.Duration) []byte {
+	scale, ok := unixScale(unit)
+	if !ok {
+		panic("bconv: illegal AppendUnixTime unit")
+	}
+	p := int64(uint64pow10[scale]) // nanoseconds per unit
+	n := t.Unix()*(int64(time.Second)/p) + int64(t.Nanosecond())/p
+	return AppendInt(dst, n, 10)
+}
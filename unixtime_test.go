@@ -0,0 +1,124 @@
+package baconv
+
+import (
+	"testing"
+	"time"
+)
+
+type parseUnixTimeTest struct {
+	in        string
+	unit      time.Duration
+	sec, nsec int64
+	err       error
+}
+
+var parseUnixTimeTests = []parseUnixTimeTest{
+	{"0", time.Second, 0, 0, nil},
+	{"1700000000", time.Second, 1700000000, 0, nil},
+	{"+1700000000", time.Second, 1700000000, 0, nil},
+	{"-1", time.Second, -1, 0, nil},
+	{"1700000000.123456", time.Second, 1700000000, 123456000, nil},
+	{"1700000000.1234567891", time.Second, 1700000000, 123456789, nil},
+	{"1700000000.000000001", time.Second, 1700000000, 1, nil},
+	{"-1.5", time.Second, -2, 500000000, nil},
+	{"1.7e9", time.Second, 1700000000, 0, nil},
+	{"17e-1", time.Second, 1, 700000000, nil},
+	{"1700000000123", time.Millisecond, 1700000000, 123000000, nil},
+	{"1700000000123.5", time.Millisecond, 1700000000, 123500000, nil},
+	{"1700000000123456", time.Microsecond, 1700000000, 123456000, nil},
+	{"1700000000123456789", time.Nanosecond, 1700000000, 123456789, nil},
+	{"1700000000123456789.9", time.Nanosecond, 1700000000, 123456789, nil},
+	{"9223372036854775807", time.Second, 1<<63 - 1, 0, nil},
+	{"9999999999999999999.9", time.Nanosecond, 9999999999, 999999999, nil},
+	{"99999999999999999990", time.Nanosecond, 99999999999, 999999990, nil},
+	{"0.0000000001", time.Second, 0, 0, nil},
+	{"1e-30", time.Second, 0, 0, nil},
+
+	// autodetect
+	{"1700000000", 0, 1700000000, 0, nil},
+	{"1700000000.123456", 0, 1700000000, 123456000, nil},
+	{"1700000000123", 0, 1700000000, 123000000, nil},
+	{"1700000000123456", 0, 1700000000, 123456000, nil},
+	{"1700000000123456789", 0, 1700000000, 123456789, nil},
+	{"-1700000000123", 0, -1700000001, 877000000, nil},
+	{"0001700000000", 0, 1700000000, 0, nil},
+	{"0", 0, 0, 0, nil},
+	{"0.5", 0, 0, 500000000, nil},
+
+	{"", time.Second, 0, 0, ErrSyntax},
+	{"-", time.Second, 0, 0, ErrSyntax},
+	{"1700000000s", time.Second, 0, 0, ErrSyntax},
+	{"1e", time.Second, 0, 0, ErrSyntax},
+	{"0x10", time.Second, 0, 0, ErrSyntax},
+	{"inf", time.Second, 0, 0, ErrSyntax},
+	{"9223372036854775808", time.Second, 0, 0, ErrRange},
+	{"1e40", time.Millisecond, 0, 0, ErrRange},
+	{"99999999999999999999", time.Nanosecond, 0, 0, ErrRange},
+}
+
+func TestParseUnixTime(t *testing.T) {
+	for _, test := range parseUnixTimeTests {
+		got, err := ParseUnixTime([]byte(test.in), test.unit)
+		if test.err != nil {
+			if err == nil {
+				t.Errorf("ParseUnixTime(%q, %v) = %v, want error %v", test.in, test.unit, got, test.err)
+			} else if err.(*NumError).Err != test.err {
+				t.Errorf("ParseUnixTime(%q, %v): error %v, want %v", test.in, test.unit, err, test.err)
+			}
+			continue
+		}
+		if err != nil || got.Unix() != test.sec || int64(got.Nanosecond()) != test.nsec {
+			t.Errorf("ParseUnixTime(%q, %v) = %d.%09d, %v, want %d.%09d",
+				test.in, test.unit, got.Unix(), got.Nanosecond(), err, test.sec, test.nsec)
+		}
+	}
+}
+
+func TestParseUnixTimeUnit(t *testing.T) {
+	_, err := ParseUnixTime([]byte("1"), time.Minute)
+	if err == nil || err.(*NumError).Err.Error() != "invalid unit 1m0s" {
+		t.Errorf("ParseUnixTime with unit time.Minute: error %v, want invalid unit", err)
+	}
+}
+
+type appendUnixTimeTest struct {
+	sec, nsec int64
+	unit      time.Duration
+	out       string
+}
+
+var appendUnixTimeTests = []appendUnixTimeTest{
+	{0, 0, time.Second, "0"},
+	{0, 0, time.Nanosecond, "0"},
+	{1700000000, 123456789, time.Second, "1700000000"},
+	{1700000000, 123456789, time.Millisecond, "1700000000123"},
+	{1700000000, 123456789, time.Microsecond, "1700000000123456"},
+	{1700000000, 123456789, time.Nanosecond, "1700000000123456789"},
+	{-1, 500000000, time.Second, "-1"},
+	{-1, 500000000, time.Millisecond, "-500"},
+	{-2, 999999999, time.Microsecond, "-1000001"},
+	{-2, 999999999, time.Nanosecond, "-1000000001"},
+}
+
+func TestAppendUnixTime(t *testing.T) {
+	for _, test := range appendUnixTimeTests {
+		tm := time.Unix(test.sec, test.nsec)
+		ba := AppendUnixTime([]byte("t="), tm, test.unit)
+		if string(ba) != "t="+test.out {
+			t.Errorf("AppendUnixTime(%v, %v) = %q, want %q", tm, test.unit, ba, "t="+test.out)
+		}
+		n, _ := ParseInt([]byte(test.out), 10, 64)
+		back, err := ParseUnixTime(ba[2:], test.unit)
+		if want := time.Unix(0, 0).Add(time.Duration(n) * test.unit); err != nil || !back.Equal(want) {
+			t.Errorf("ParseUnixTime(%q, %v) = %v, %v, want %v", ba[2:], test.unit, back, err, want)
+		}
+	}
+}
+
+func BenchmarkParseUnixTime(b *testing.B) {
+	ba := []byte("1700000000.123456")
+	for i := 0; i < b.N; i++ {
+		t, _ := ParseUnixTime(ba, 0)
+		BenchSink += t.Nanosecond()
+	}
+}
@@ -0,0 +1,79 @@
+package baconv
+
+import (
+	"unicode/utf8"
+)
+
+// inRanges reports whether r lies in one of the inclusive ranges listed
+// as pairs in rang16 or rang32, depending on its size.
+func inRanges(r rune, rang16 []uint16, rang32 []uint32) bool {
+	if r < 1<<16 {
+		rr := uint16(r)
+		i := bsearch16(rang16, rr)
+		return i < len(rang16) && rang16[i&^1] <= rr && rr <= rang16[i|1]
+	}
+	rr := uint32(r)
+	i := bsearch32(rang32, rr)
+	return i < len(rang32) && rang32[i&^1] <= rr && rr <= rang32[i|1]
+}
+
+// RuneWidth returns the number of columns r occupies on a terminal:
+// 0 for control characters, combining marks and other zero-width runes,
+// 2 for East Asian Wide and Fullwidth runes, and 1 for everything else,
+// including runes of ambiguous width.
+func RuneWidth(r rune) int {
+	switch {
+	case r < 0x20 || 0x7F <= r && r < 0xA0:
+		return 0
+	case r < 0x300: // no combining, zero-width or wide runes below U+0300
+		return 1
+	case inRanges(r, isCombining16, isCombining32) || inRanges(r, isZeroWidth16, isZeroWidth32):
+		return 0
+	case inRanges(r, isWide16, isWide32):
+		return 2
+	}
+	return 1
+}
+
+// DisplayWidth returns the number of columns ba occupies on a terminal,
+// the sum of RuneWidth over its runes. Each byte of invalid UTF-8 counts
+// as one column, the width of the utf8.RuneError it is displayed as.
+func DisplayWidth(ba []byte) int {
+	n := 0
+	for i := 0; i < len(ba); {
+		if c := ba[i]; c < utf8.RuneSelf {
+			if 0x20 <= c && c != 0x7F {
+				n++
+			}
+			i++
+			continue
+		}
+		r, width := utf8.DecodeRune(ba[i:])
+		n += RuneWidth(r)
+		i += width
+	}
+	return n
+}
+
+// AppendPadded appends ba to dst, padded with spaces to a display width of
+// at least |width| columns as computed by DisplayWidth, and returns the
+// extended buffer. As with fmt's %*s, a positive width pads on the left,
+// right-aligning ba, and a negative width pads on the right. ba is never
+// truncated.
+func AppendPadded(dst, ba []byte, width int) []byte {
+	left := width > 0
+	if !left {
+		width = -width
+	}
+	pad := width - DisplayWidth(ba)
+	if !left {
+		dst = append(dst, ba...)
+	}
+	for ; pad > 0; pad-- {
+		dst = append(dst, ' ')
+	}
+	if left {
+		dst = append(dst, ba...)
+	}
+	return dst
+}
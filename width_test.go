@@ -0,0 +1,91 @@
+package baconv
+
+import (
+	"testing"
+	"unicode"
+)
+
+var displayWidthTests = []struct {
+	in    string
+	width int
+}{
+	{"", 0},
+	{"hello", 5},
+	{"a\tb\x7f\x00", 2},
+	{"\u00e9t\u00e9", 3},   // precomposed
+	{"e\u0301te\u0301", 3}, // combining acute accents
+	{"\u00ad", 1},          // soft hyphen
+	{"a\u200bb", 2},        // zero width space
+	{"\u4e16\u754c", 4},    // 世界
+	{"Hello, 世界", 11},
+	{"\uff21\uff22", 4},       // fullwidth AB
+	{"\uff71", 1},             // halfwidth katakana
+	{"\u1100\u1161\u11a8", 2}, // conjoining Hangul syllable
+	{"\uac01", 2},             // precomposed Hangul syllable
+	{"\U0001F600", 2},         // emoji
+	{"\U00020000", 2},         // CJK Extension B
+	{"\u00a1\u00b1", 2},       // ambiguous width
+	{"\xff\xfe", 2},           // invalid UTF-8
+	{"\xe4\xb8", 2},           // truncated 世
+}
+
+func TestDisplayWidth(t *testing.T) {
+	for _, tt := range displayWidthTests {
+		if n := DisplayWidth([]byte(tt.in)); n != tt.width {
+			t.Errorf("DisplayWidth(%q) = %d, want %d", tt.in, n, tt.width)
+		}
+	}
+}
+
+func TestAppendPadded(t *testing.T) {
+	tests := []struct {
+		in    string
+		width int
+		out   string
+	}{
+		{"", 0, ""},
+		{"", 3, "   "},
+		{"ab", 4, "  ab"},
+		{"ab", -4, "ab  "},
+		{"abcdef", 4, "abcdef"},
+		{"abcdef", -4, "abcdef"},
+		{"世界", 6, "  世界"},
+		{"世界", -6, "世界  "},
+		{"世界", 3, "世界"},
+		{"é", -3, "é  "},
+	}
+	for _, tt := range tests {
+		out := AppendPadded([]byte("x:"), []byte(tt.in), tt.width)
+		if string(out) != "x:"+tt.out {
+			t.Errorf("AppendPadded(%q, %q, %d) = %q, want %q", "x:", tt.in, tt.width, out, "x:"+tt.out)
+		}
+	}
+}
+
+// Verify that the zero-width tables agree with the unicode package.
+func TestRuneWidthZero(t *testing.T) {
+	n := 0
+	for r := rune(0x300); r <= unicode.MaxRune; r++ {
+		zero := unicode.In(r, unicode.Mn, unicode.Me) ||
+			r != 0x00AD && unicode.Is(unicode.Cf, r) ||
+			0x1160 <= r && r <= 0x11FF || 0xD7B0 <= r && r <= 0xD7FF
+		if (RuneWidth(r) == 0) != zero {
+			t.Errorf("RuneWidth(%U) = %d incorrect", r, RuneWidth(r))
+			n++
+			if n > 10 {
+				return
+			}
+		}
+	}
+}
+
+func BenchmarkDisplayWidth(b *testing.B) {
+	for _, s := range []string{"The quick brown fox", "Hello, 世界! こんにちは"} {
+		ba := []byte(s)
+		b.Run(s, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				BenchSink += DisplayWidth(ba)
+			}
+		})
+	}
+}
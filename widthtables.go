@@ -0,0 +1,533 @@
+// Code generated by go run makeisprint.go -output isprint.go -width widthtables.go; DO NOT EDIT.
+
+package baconv
+
+// eastAsianWidthVersion is the version of EastAsianWidth.txt the isWide
+// tables were generated from.
+const eastAsianWidthVersion = "14.0.0"
+
+// isWide16 and isWide32 list the ranges of East Asian Wide and Fullwidth runes.
+var isWide16 = []uint16{
+	0x1100, 0x115f,
+	0x231a, 0x231b,
+	0x2329, 0x232a,
+	0x23e9, 0x23ec,
+	0x23f0, 0x23f0,
+	0x23f3, 0x23f3,
+	0x25fd, 0x25fe,
+	0x2614, 0x2615,
+	0x2648, 0x2653,
+	0x267f, 0x267f,
+	0x2693, 0x2693,
+	0x26a1, 0x26a1,
+	0x26aa, 0x26ab,
+	0x26bd, 0x26be,
+	0x26c4, 0x26c5,
+	0x26ce, 0x26ce,
+	0x26d4, 0x26d4,
+	0x26ea, 0x26ea,
+	0x26f2, 0x26f3,
+	0x26f5, 0x26f5,
+	0x26fa, 0x26fa,
+	0x26fd, 0x26fd,
+	0x2705, 0x2705,
+	0x270a, 0x270b,
+	0x2728, 0x2728,
+	0x274c, 0x274c,
+	0x274e, 0x274e,
+	0x2753, 0x2755,
+	0x2757, 0x2757,
+	0x2795, 0x2797,
+	0x27b0, 0x27b0,
+	0x27bf, 0x27bf,
+	0x2b1b, 0x2b1c,
+	0x2b50, 0x2b50,
+	0x2b55, 0x2b55,
+	0x2e80, 0x2e99,
+	0x2e9b, 0x2ef3,
+	0x2f00, 0x2fd5,
+	0x2ff0, 0x2ffb,
+	0x3000, 0x303e,
+	0x3041, 0x3096,
+	0x3099, 0x30ff,
+	0x3105, 0x312f,
+	0x3131, 0x318e,
+	0x3190, 0x31e3,
+	0x31f0, 0x321e,
+	0x3220, 0x3247,
+	0x3250, 0x4dbf,
+	0x4e00, 0xa48c,
+	0xa490, 0xa4c6,
+	0xa960, 0xa97c,
+	0xac00, 0xd7a3,
+	0xf900, 0xfaff,
+	0xfe10, 0xfe19,
+	0xfe30, 0xfe52,
+	0xfe54, 0xfe66,
+	0xfe68, 0xfe6b,
+	0xff01, 0xff60,
+	0xffe0, 0xffe6,
+}
+
+var isWide32 = []uint32{
+	0x016fe0, 0x016fe4,
+	0x016ff0, 0x016ff1,
+	0x017000, 0x0187f7,
+	0x018800, 0x018cd5,
+	0x018d00, 0x018d08,
+	0x01aff0, 0x01aff3,
+	0x01aff5, 0x01affb,
+	0x01affd, 0x01affe,
+	0x01b000, 0x01b122,
+	0x01b150, 0x01b152,
+	0x01b164, 0x01b167,
+	0x01b170, 0x01b2fb,
+	0x01f004, 0x01f004,
+	0x01f0cf, 0x01f0cf,
+	0x01f18e, 0x01f18e,
+	0x01f191, 0x01f19a,
+	0x01f200, 0x01f202,
+	0x01f210, 0x01f23b,
+	0x01f240, 0x01f248,
+	0x01f250, 0x01f251,
+	0x01f260, 0x01f265,
+	0x01f300, 0x01f320,
+	0x01f32d, 0x01f335,
+	0x01f337, 0x01f37c,
+	0x01f37e, 0x01f393,
+	0x01f3a0, 0x01f3ca,
+	0x01f3cf, 0x01f3d3,
+	0x01f3e0, 0x01f3f0,
+	0x01f3f4, 0x01f3f4,
+	0x01f3f8, 0x01f43e,
+	0x01f440, 0x01f440,
+	0x01f442, 0x01f4fc,
+	0x01f4ff, 0x01f53d,
+	0x01f54b, 0x01f54e,
+	0x01f550, 0x01f567,
+	0x01f57a, 0x01f57a,
+	0x01f595, 0x01f596,
+	0x01f5a4, 0x01f5a4,
+	0x01f5fb, 0x01f64f,
+	0x01f680, 0x01f6c5,
+	0x01f6cc, 0x01f6cc,
+	0x01f6d0, 0x01f6d2,
+	0x01f6d5, 0x01f6d7,
+	0x01f6dd, 0x01f6df,
+	0x01f6eb, 0x01f6ec,
+	0x01f6f4, 0x01f6fc,
+	0x01f7e0, 0x01f7eb,
+	0x01f7f0, 0x01f7f0,
+	0x01f90c, 0x01f93a,
+	0x01f93c, 0x01f945,
+	0x01f947, 0x01f9ff,
+	0x01fa70, 0x01fa74,
+	0x01fa78, 0x01fa7c,
+	0x01fa80, 0x01fa86,
+	0x01fa90, 0x01faac,
+	0x01fab0, 0x01faba,
+	0x01fac0, 0x01fac5,
+	0x01fad0, 0x01fad9,
+	0x01fae0, 0x01fae7,
+	0x01faf0, 0x01faf6,
+	0x020000, 0x02fffd,
+	0x030000, 0x03fffd,
+}
+
+// isZeroWidth16 and isZeroWidth32 list the ranges of format characters and conjoining Hangul jamo.
+var isZeroWidth16 = []uint16{
+	0x0600, 0x0605,
+	0x061c, 0x061c,
+	0x06dd, 0x06dd,
+	0x070f, 0x070f,
+	0x0890, 0x0891,
+	0x08e2, 0x08e2,
+	0x1160, 0x11ff,
+	0x180e, 0x180e,
+	0x200b, 0x200f,
+	0x202a, 0x202e,
+	0x2060, 0x2064,
+	0x2066, 0x206f,
+	0xd7b0, 0xd7ff,
+	0xfeff, 0xfeff,
+	0xfff9, 0xfffb,
+}
+
+var isZeroWidth32 = []uint32{
+	0x0110bd, 0x0110bd,
+	0x0110cd, 0x0110cd,
+	0x013430, 0x01343f,
+	0x01bca0, 0x01bca3,
+	0x01d173, 0x01d17a,
+	0x0e0001, 0x0e0001,
+	0x0e0020, 0x0e007f,
+}
+
+// isCombining16 and isCombining32 list the ranges of nonspacing and enclosing marks.
+var isCombining16 = []uint16{
+	0x0300, 0x036f,
+	0x0483, 0x0489,
+	0x0591, 0x05bd,
+	0x05bf, 0x05bf,
+	0x05c1, 0x05c2,
+	0x05c4, 0x05c5,
+	0x05c7, 0x05c7,
+	0x0610, 0x061a,
+	0x064b, 0x065f,
+	0x0670, 0x0670,
+	0x06d6, 0x06dc,
+	0x06df, 0x06e4,
+	0x06e7, 0x06e8,
+	0x06ea, 0x06ed,
+	0x0711, 0x0711,
+	0x0730, 0x074a,
+	0x07a6, 0x07b0,
+	0x07eb, 0x07f3,
+	0x07fd, 0x07fd,
+	0x0816, 0x0819,
+	0x081b, 0x0823,
+	0x0825, 0x0827,
+	0x0829, 0x082d,
+	0x0859, 0x085b,
+	0x0897, 0x089f,
+	0x08ca, 0x08e1,
+	0x08e3, 0x0902,
+	0x093a, 0x093a,
+	0x093c, 0x093c,
+	0x0941, 0x0948,
+	0x094d, 0x094d,
+	0x0951, 0x0957,
+	0x0962, 0x0963,
+	0x0981, 0x0981,
+	0x09bc, 0x09bc,
+	0x09c1, 0x09c4,
+	0x09cd, 0x09cd,
+	0x09e2, 0x09e3,
+	0x09fe, 0x09fe,
+	0x0a01, 0x0a02,
+	0x0a3c, 0x0a3c,
+	0x0a41, 0x0a42,
+	0x0a47, 0x0a48,
+	0x0a4b, 0x0a4d,
+	0x0a51, 0x0a51,
+	0x0a70, 0x0a71,
+	0x0a75, 0x0a75,
+	0x0a81, 0x0a82,
+	0x0abc, 0x0abc,
+	0x0ac1, 0x0ac5,
+	0x0ac7, 0x0ac8,
+	0x0acd, 0x0acd,
+	0x0ae2, 0x0ae3,
+	0x0afa, 0x0aff,
+	0x0b01, 0x0b01,
+	0x0b3c, 0x0b3c,
+	0x0b3f, 0x0b3f,
+	0x0b41, 0x0b44,
+	0x0b4d, 0x0b4d,
+	0x0b55, 0x0b56,
+	0x0b62, 0x0b63,
+	0x0b82, 0x0b82,
+	0x0bc0, 0x0bc0,
+	0x0bcd, 0x0bcd,
+	0x0c00, 0x0c00,
+	0x0c04, 0x0c04,
+	0x0c3c, 0x0c3c,
+	0x0c3e, 0x0c40,
+	0x0c46, 0x0c48,
+	0x0c4a, 0x0c4d,
+	0x0c55, 0x0c56,
+	0x0c62, 0x0c63,
+	0x0c81, 0x0c81,
+	0x0cbc, 0x0cbc,
+	0x0cbf, 0x0cbf,
+	0x0cc6, 0x0cc6,
+	0x0ccc, 0x0ccd,
+	0x0ce2, 0x0ce3,
+	0x0d00, 0x0d01,
+	0x0d3b, 0x0d3c,
+	0x0d41, 0x0d44,
+	0x0d4d, 0x0d4d,
+	0x0d62, 0x0d63,
+	0x0d81, 0x0d81,
+	0x0dca, 0x0dca,
+	0x0dd2, 0x0dd4,
+	0x0dd6, 0x0dd6,
+	0x0e31, 0x0e31,
+	0x0e34, 0x0e3a,
+	0x0e47, 0x0e4e,
+	0x0eb1, 0x0eb1,
+	0x0eb4, 0x0ebc,
+	0x0ec8, 0x0ece,
+	0x0f18, 0x0f19,
+	0x0f35, 0x0f35,
+	0x0f37, 0x0f37,
+	0x0f39, 0x0f39,
+	0x0f71, 0x0f7e,
+	0x0f80, 0x0f84,
+	0x0f86, 0x0f87,
+	0x0f8d, 0x0f97,
+	0x0f99, 0x0fbc,
+	0x0fc6, 0x0fc6,
+	0x102d, 0x1030,
+	0x1032, 0x1037,
+	0x1039, 0x103a,
+	0x103d, 0x103e,
+	0x1058, 0x1059,
+	0x105e, 0x1060,
+	0x1071, 0x1074,
+	0x1082, 0x1082,
+	0x1085, 0x1086,
+	0x108d, 0x108d,
+	0x109d, 0x109d,
+	0x135d, 0x135f,
+	0x1712, 0x1714,
+	0x1732, 0x1733,
+	0x1752, 0x1753,
+	0x1772, 0x1773,
+	0x17b4, 0x17b5,
+	0x17b7, 0x17bd,
+	0x17c6, 0x17c6,
+	0x17c9, 0x17d3,
+	0x17dd, 0x17dd,
+	0x180b, 0x180d,
+	0x180f, 0x180f,
+	0x1885, 0x1886,
+	0x18a9, 0x18a9,
+	0x1920, 0x1922,
+	0x1927, 0x1928,
+	0x1932, 0x1932,
+	0x1939, 0x193b,
+	0x1a17, 0x1a18,
+	0x1a1b, 0x1a1b,
+	0x1a56, 0x1a56,
+	0x1a58, 0x1a5e,
+	0x1a60, 0x1a60,
+	0x1a62, 0x1a62,
+	0x1a65, 0x1a6c,
+	0x1a73, 0x1a7c,
+	0x1a7f, 0x1a7f,
+	0x1ab0, 0x1add,
+	0x1ae0, 0x1aeb,
+	0x1b00, 0x1b03,
+	0x1b34, 0x1b34,
+	0x1b36, 0x1b3a,
+	0x1b3c, 0x1b3c,
+	0x1b42, 0x1b42,
+	0x1b6b, 0x1b73,
+	0x1b80, 0x1b81,
+	0x1ba2, 0x1ba5,
+	0x1ba8, 0x1ba9,
+	0x1bab, 0x1bad,
+	0x1be6, 0x1be6,
+	0x1be8, 0x1be9,
+	0x1bed, 0x1bed,
+	0x1bef, 0x1bf1,
+	0x1c2c, 0x1c33,
+	0x1c36, 0x1c37,
+	0x1cd0, 0x1cd2,
+	0x1cd4, 0x1ce0,
+	0x1ce2, 0x1ce8,
+	0x1ced, 0x1ced,
+	0x1cf4, 0x1cf4,
+	0x1cf8, 0x1cf9,
+	0x1dc0, 0x1dff,
+	0x20d0, 0x20f0,
+	0x2cef, 0x2cf1,
+	0x2d7f, 0x2d7f,
+	0x2de0, 0x2dff,
+	0x302a, 0x302d,
+	0x3099, 0x309a,
+	0xa66f, 0xa672,
+	0xa674, 0xa67d,
+	0xa69e, 0xa69f,
+	0xa6f0, 0xa6f1,
+	0xa802, 0xa802,
+	0xa806, 0xa806,
+	0xa80b, 0xa80b,
+	0xa825, 0xa826,
+	0xa82c, 0xa82c,
+	0xa8c4, 0xa8c5,
+	0xa8e0, 0xa8f1,
+	0xa8ff, 0xa8ff,
+	0xa926, 0xa92d,
+	0xa947, 0xa951,
+	0xa980, 0xa982,
+	0xa9b3, 0xa9b3,
+	0xa9b6, 0xa9b9,
+	0xa9bc, 0xa9bd,
+	0xa9e5, 0xa9e5,
+	0xaa29, 0xaa2e,
+	0xaa31, 0xaa32,
+	0xaa35, 0xaa36,
+	0xaa43, 0xaa43,
+	0xaa4c, 0xaa4c,
+	0xaa7c, 0xaa7c,
+	0xaab0, 0xaab0,
+	0xaab2, 0xaab4,
+	0xaab7, 0xaab8,
+	0xaabe, 0xaabf,
+	0xaac1, 0xaac1,
+	0xaaec, 0xaaed,
+	0xaaf6, 0xaaf6,
+	0xabe5, 0xabe5,
+	0xabe8, 0xabe8,
+	0xabed, 0xabed,
+	0xfb1e, 0xfb1e,
+	0xfe00, 0xfe0f,
+	0xfe20, 0xfe2f,
+}
+
+var isCombining32 = []uint32{
+	0x0101fd, 0x0101fd,
+	0x0102e0, 0x0102e0,
+	0x010376, 0x01037a,
+	0x010a01, 0x010a03,
+	0x010a05, 0x010a06,
+	0x010a0c, 0x010a0f,
+	0x010a38, 0x010a3a,
+	0x010a3f, 0x010a3f,
+	0x010ae5, 0x010ae6,
+	0x010d24, 0x010d27,
+	0x010d69, 0x010d6d,
+	0x010eab, 0x010eac,
+	0x010efa, 0x010eff,
+	0x010f46, 0x010f50,
+	0x010f82, 0x010f85,
+	0x011001, 0x011001,
+	0x011038, 0x011046,
+	0x011070, 0x011070,
+	0x011073, 0x011074,
+	0x01107f, 0x011081,
+	0x0110b3, 0x0110b6,
+	0x0110b9, 0x0110ba,
+	0x0110c2, 0x0110c2,
+	0x011100, 0x011102,
+	0x011127, 0x01112b,
+	0x01112d, 0x011134,
+	0x011173, 0x011173,
+	0x011180, 0x011181,
+	0x0111b6, 0x0111be,
+	0x0111c9, 0x0111cc,
+	0x0111cf, 0x0111cf,
+	0x01122f, 0x011231,
+	0x011234, 0x011234,
+	0x011236, 0x011237,
+	0x01123e, 0x01123e,
+	0x011241, 0x011241,
+	0x0112df, 0x0112df,
+	0x0112e3, 0x0112ea,
+	0x011300, 0x011301,
+	0x01133b, 0x01133c,
+	0x011340, 0x011340,
+	0x011366, 0x01136c,
+	0x011370, 0x011374,
+	0x0113bb, 0x0113c0,
+	0x0113ce, 0x0113ce,
+	0x0113d0, 0x0113d0,
+	0x0113d2, 0x0113d2,
+	0x0113e1, 0x0113e2,
+	0x011438, 0x01143f,
+	0x011442, 0x011444,
+	0x011446, 0x011446,
+	0x01145e, 0x01145e,
+	0x0114b3, 0x0114b8,
+	0x0114ba, 0x0114ba,
+	0x0114bf, 0x0114c0,
+	0x0114c2, 0x0114c3,
+	0x0115b2, 0x0115b5,
+	0x0115bc, 0x0115bd,
+	0x0115bf, 0x0115c0,
+	0x0115dc, 0x0115dd,
+	0x011633, 0x01163a,
+	0x01163d, 0x01163d,
+	0x01163f, 0x011640,
+	0x0116ab, 0x0116ab,
+	0x0116ad, 0x0116ad,
+	0x0116b0, 0x0116b5,
+	0x0116b7, 0x0116b7,
+	0x01171d, 0x01171d,
+	0x01171f, 0x01171f,
+	0x011722, 0x011725,
+	0x011727, 0x01172b,
+	0x01182f, 0x011837,
+	0x011839, 0x01183a,
+	0x01193b, 0x01193c,
+	0x01193e, 0x01193e,
+	0x011943, 0x011943,
+	0x0119d4, 0x0119d7,
+	0x0119da, 0x0119db,
+	0x0119e0, 0x0119e0,
+	0x011a01, 0x011a0a,
+	0x011a33, 0x011a38,
+	0x011a3b, 0x011a3e,
+	0x011a47, 0x011a47,
+	0x011a51, 0x011a56,
+	0x011a59, 0x011a5b,
+	0x011a8a, 0x011a96,
+	0x011a98, 0x011a99,
+	0x011b60, 0x011b60,
+	0x011b62, 0x011b64,
+	0x011b66, 0x011b66,
+	0x011c30, 0x011c36,
+	0x011c38, 0x011c3d,
+	0x011c3f, 0x011c3f,
+	0x011c92, 0x011ca7,
+	0x011caa, 0x011cb0,
+	0x011cb2, 0x011cb3,
+	0x011cb5, 0x011cb6,
+	0x011d31, 0x011d36,
+	0x011d3a, 0x011d3a,
+	0x011d3c, 0x011d3d,
+	0x011d3f, 0x011d45,
+	0x011d47, 0x011d47,
+	0x011d90, 0x011d91,
+	0x011d95, 0x011d95,
+	0x011d97, 0x011d97,
+	0x011ef3, 0x011ef4,
+	0x011f00, 0x011f01,
+	0x011f36, 0x011f3a,
+	0x011f40, 0x011f40,
+	0x011f42, 0x011f42,
+	0x011f5a, 0x011f5a,
+	0x013440, 0x013440,
+	0x013447, 0x013455,
+	0x01611e, 0x016129,
+	0x01612d, 0x01612f,
+	0x016af0, 0x016af4,
+	0x016b30, 0x016b36,
+	0x016f4f, 0x016f4f,
+	0x016f8f, 0x016f92,
+	0x016fe4, 0x016fe4,
+	0x01bc9d, 0x01bc9e,
+	0x01cf00, 0x01cf2d,
+	0x01cf30, 0x01cf46,
+	0x01d167, 0x01d169,
+	0x01d17b, 0x01d182,
+	0x01d185, 0x01d18b,
+	0x01d1aa, 0x01d1ad,
+	0x01d242, 0x01d244,
+	0x01da00, 0x01da36,
+	0x01da3b, 0x01da6c,
+	0x01da75, 0x01da75,
+	0x01da84, 0x01da84,
+	0x01da9b, 0x01da9f,
+	0x01daa1, 0x01daaf,
+	0x01e000, 0x01e006,
+	0x01e008, 0x01e018,
+	0x01e01b, 0x01e021,
+	0x01e023, 0x01e024,
+	0x01e026, 0x01e02a,
+	0x01e08f, 0x01e08f,
+	0x01e130, 0x01e136,
+	0x01e2ae, 0x01e2ae,
+	0x01e2ec, 0x01e2ef,
+	0x01e4ec, 0x01e4ef,
+	0x01e5ee, 0x01e5ef,
+	0x01e6e3, 0x01e6e3,
+	0x01e6e6, 0x01e6e6,
+	0x01e6ee, 0x01e6ef,
+	0x01e6f5, 0x01e6f5,
+	0x01e8d0, 0x01e8d6,
+	0x01e944, 0x01e94a,
+	0x0e0100, 0x0e01ef,
+}